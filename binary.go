@@ -0,0 +1,761 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package dataframe
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// binaryMagic identifies the native binary format.
+var binaryMagic = []byte("DFGO")
+
+// binaryVersion is the current version of the native binary format.
+const binaryVersion = 1
+
+// ErrBinaryInvalid signifies that the data is not in the native binary format
+// or is corrupt.
+var ErrBinaryInvalid = errors.New("invalid binary dataframe")
+
+// BinarySeries is implemented by Series that can be serialized in the native binary format.
+type BinarySeries interface {
+	Series
+	encoding.BinaryMarshaler
+	encoding.BinaryUnmarshaler
+}
+
+var (
+	binaryRegistryLock sync.RWMutex
+	binaryIDs          = map[reflect.Type]string{}
+	binaryTypes        = map[string]reflect.Type{}
+)
+
+func init() {
+	RegisterSeries("float64", &SeriesFloat64{})
+	RegisterSeries("int64", &SeriesInt64{})
+	RegisterSeries("string", &SeriesString{})
+	RegisterSeries("time", &SeriesTime{})
+	RegisterSeries("duration", &SeriesDuration{})
+	RegisterSeries("mixed", &SeriesMixed{})
+	RegisterSeries("generic", &SeriesGeneric{})
+
+	// Allow time.Time and time.Duration values in SeriesMixed to be serialized
+	gob.Register(time.Time{})
+	gob.Register(time.Duration(0))
+}
+
+// RegisterSeries registers a custom Series so it can be serialized in the native binary format.
+// The id must be unique and must not change between the time the data is saved and loaded.
+// s must be a pointer.
+//
+// Example:
+//
+//  func init() {
+//      dataframe.RegisterSeries("complex128", &SeriesComplex128{})
+//  }
+//
+func RegisterSeries(id string, s BinarySeries) {
+	binaryRegistryLock.Lock()
+	defer binaryRegistryLock.Unlock()
+
+	typ := reflect.TypeOf(s)
+	if typ.Kind() != reflect.Ptr {
+		panic("series must be a pointer")
+	}
+
+	if _, exists := binaryTypes[id]; exists {
+		panic(fmt.Sprintf("series id already registered: %s", id))
+	}
+
+	binaryIDs[typ] = id
+	binaryTypes[id] = typ.Elem()
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+// The data is encoded in a compact versioned format which preserves the type, name and values (including nils) of each Series.
+// Every Series must have been registered with RegisterSeries.
+//
+// NOTE: Custom ValueToStringFormatter, IsEqualFunc and IsLessThanFunc functions are not preserved.
+func (df *DataFrame) MarshalBinary() ([]byte, error) {
+	df.lock.RLock()
+	defer df.lock.RUnlock()
+
+	var e binaryEncoder
+	e.Write(binaryMagic)
+	e.WriteByte(binaryVersion)
+	e.writeUvarint(uint64(len(df.Series)))
+
+	binaryRegistryLock.RLock()
+	defer binaryRegistryLock.RUnlock()
+
+	for _, s := range df.Series {
+		bs, ok := s.(BinarySeries)
+		if !ok {
+			return nil, fmt.Errorf("series %s does not support binary serialization", s.Name())
+		}
+
+		id, exists := binaryIDs[reflect.TypeOf(s)]
+		if !exists {
+			return nil, fmt.Errorf("series %s has not been registered", s.Name())
+		}
+
+		data, err := bs.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+
+		e.writeString(id)
+		e.writeBytes(data)
+	}
+
+	return e.Bytes(), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+// It replaces the contents of the DataFrame with data produced by MarshalBinary.
+func (df *DataFrame) UnmarshalBinary(data []byte) error {
+
+	d := newBinaryDecoder(data)
+
+	magic := d.readN(len(binaryMagic))
+	if d.err != nil || !bytes.Equal(magic, binaryMagic) {
+		return ErrBinaryInvalid
+	}
+
+	if v := d.readByte(); d.err != nil || v > binaryVersion {
+		return fmt.Errorf("unsupported binary format version: %d", v)
+	}
+
+	nSeries := d.readLen()
+	if d.err != nil {
+		return d.err
+	}
+
+	binaryRegistryLock.RLock()
+	defer binaryRegistryLock.RUnlock()
+
+	seriess := make([]Series, 0, nSeries)
+	for i := 0; i < nSeries; i++ {
+		id := d.readString()
+		payload := d.readBytes()
+		if d.err != nil {
+			return d.err
+		}
+
+		typ, exists := binaryTypes[id]
+		if !exists {
+			return fmt.Errorf("unknown series id: %s", id)
+		}
+
+		s := reflect.New(typ).Interface().(BinarySeries)
+		if err := s.UnmarshalBinary(payload); err != nil {
+			return err
+		}
+		seriess = append(seriess, s)
+	}
+
+	if d.remaining() != 0 {
+		return ErrBinaryInvalid
+	}
+
+	// Validate series before modifying df
+	names := map[string]struct{}{}
+	for _, s := range seriess {
+		if s.NRows() != seriess[0].NRows() {
+			return ErrBinaryInvalid
+		}
+		if _, exists := names[s.Name()]; exists {
+			return ErrBinaryInvalid
+		}
+		names[s.Name()] = struct{}{}
+	}
+
+	newDF := NewDataFrame(seriess...)
+
+	df.lock.Lock()
+	defer df.lock.Unlock()
+
+	df.Series = newDF.Series
+	df.n = newDF.n
+
+	return nil
+}
+
+// GobEncode implements the gob.GobEncoder interface.
+func (df *DataFrame) GobEncode() ([]byte, error) {
+	return df.MarshalBinary()
+}
+
+// GobDecode implements the gob.GobDecoder interface.
+func (df *DataFrame) GobDecode(data []byte) error {
+	return df.UnmarshalBinary(data)
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (s *SeriesFloat64) MarshalBinary() ([]byte, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	var e binaryEncoder
+	e.writeString(s.name)
+	e.writeUvarint(uint64(len(s.Values)))
+
+	var buf [8]byte
+	for _, v := range s.Values {
+		binary.LittleEndian.PutUint64(buf[:], math.Float64bits(v))
+		e.Write(buf[:])
+	}
+
+	return e.Bytes(), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (s *SeriesFloat64) UnmarshalBinary(data []byte) error {
+
+	d := newBinaryDecoder(data)
+	name := d.readString()
+	n := d.readLen()
+
+	vals := make([]float64, 0, n)
+	nilCount := 0
+	for i := 0; i < n && d.err == nil; i++ {
+		v := math.Float64frombits(binary.LittleEndian.Uint64(d.readN(8)))
+		if math.IsNaN(v) {
+			nilCount++
+		}
+		vals = append(vals, v)
+	}
+	if err := d.done(); err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.name = name
+	s.Values = vals
+	s.nilCount = nilCount
+	if s.valFormatter == nil {
+		s.valFormatter = DefaultValueFormatter
+	}
+
+	return nil
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (s *SeriesInt64) MarshalBinary() ([]byte, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	var e binaryEncoder
+	e.writeString(s.name)
+	e.writeUvarint(uint64(len(s.values)))
+	e.writeNils(len(s.values), func(i int) bool { return s.values[i] == nil })
+
+	for _, v := range s.values {
+		if v != nil {
+			e.writeVarint(*v)
+		}
+	}
+
+	return e.Bytes(), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (s *SeriesInt64) UnmarshalBinary(data []byte) error {
+
+	d := newBinaryDecoder(data)
+	name := d.readString()
+	n := d.readRows()
+	nils := d.readNils(n)
+
+	vals := make([]*int64, n)
+	nilCount := 0
+	for i := 0; i < n && d.err == nil; i++ {
+		if nils[i] {
+			nilCount++
+			continue
+		}
+		v := d.readVarint()
+		vals[i] = &v
+	}
+	if err := d.done(); err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.name = name
+	s.values = vals
+	s.nilCount = nilCount
+	if s.valFormatter == nil {
+		s.valFormatter = DefaultValueFormatter
+	}
+
+	return nil
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (s *SeriesString) MarshalBinary() ([]byte, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	var e binaryEncoder
+	e.writeString(s.name)
+	e.writeUvarint(uint64(len(s.values)))
+	e.writeNils(len(s.values), func(i int) bool { return s.values[i] == nil })
+
+	for _, v := range s.values {
+		if v != nil {
+			e.writeString(*v)
+		}
+	}
+
+	return e.Bytes(), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (s *SeriesString) UnmarshalBinary(data []byte) error {
+
+	d := newBinaryDecoder(data)
+	name := d.readString()
+	n := d.readRows()
+	nils := d.readNils(n)
+
+	vals := make([]*string, n)
+	nilCount := 0
+	for i := 0; i < n && d.err == nil; i++ {
+		if nils[i] {
+			nilCount++
+			continue
+		}
+		v := d.readString()
+		vals[i] = &v
+	}
+	if err := d.done(); err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.name = name
+	s.values = vals
+	s.nilCount = nilCount
+	if s.valFormatter == nil {
+		s.valFormatter = DefaultValueFormatter
+	}
+
+	return nil
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+// The time zone of each value is preserved as an offset from UTC.
+func (s *SeriesTime) MarshalBinary() ([]byte, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	var e binaryEncoder
+	e.writeString(s.name)
+	e.writeString(s.Layout)
+	e.writeUvarint(uint64(len(s.Values)))
+	e.writeNils(len(s.Values), func(i int) bool { return s.Values[i] == nil })
+
+	for _, v := range s.Values {
+		if v != nil {
+			b, err := v.MarshalBinary()
+			if err != nil {
+				return nil, err
+			}
+			e.writeBytes(b)
+		}
+	}
+
+	return e.Bytes(), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (s *SeriesTime) UnmarshalBinary(data []byte) error {
+
+	d := newBinaryDecoder(data)
+	name := d.readString()
+	layout := d.readString()
+	n := d.readRows()
+	nils := d.readNils(n)
+
+	vals := make([]*time.Time, n)
+	nilCount := 0
+	for i := 0; i < n && d.err == nil; i++ {
+		if nils[i] {
+			nilCount++
+			continue
+		}
+		b := d.readBytes()
+		if d.err != nil {
+			break
+		}
+		v := time.Time{}
+		if err := v.UnmarshalBinary(b); err != nil {
+			return err
+		}
+		vals[i] = &v
+	}
+	if err := d.done(); err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.name = name
+	s.Layout = layout
+	s.Values = vals
+	s.nilCount = nilCount
+	if s.valFormatter == nil {
+		s.valFormatter = DefaultValueFormatter
+	}
+
+	return nil
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (s *SeriesDuration) MarshalBinary() ([]byte, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	var e binaryEncoder
+	e.writeString(s.name)
+	e.writeUvarint(uint64(len(s.Values)))
+	e.writeNils(len(s.Values), func(i int) bool { return s.Values[i] == nil })
+
+	for _, v := range s.Values {
+		if v != nil {
+			e.writeVarint(int64(*v))
+		}
+	}
+
+	return e.Bytes(), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (s *SeriesDuration) UnmarshalBinary(data []byte) error {
+
+	d := newBinaryDecoder(data)
+	name := d.readString()
+	n := d.readRows()
+	nils := d.readNils(n)
+
+	vals := make([]*time.Duration, n)
+	nilCount := 0
+	for i := 0; i < n && d.err == nil; i++ {
+		if nils[i] {
+			nilCount++
+			continue
+		}
+		v := time.Duration(d.readVarint())
+		vals[i] = &v
+	}
+	if err := d.done(); err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.name = name
+	s.Values = vals
+	s.nilCount = nilCount
+	if s.valFormatter == nil {
+		s.valFormatter = DefaultValueFormatter
+	}
+
+	return nil
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+// Values are encoded using the encoding/gob package. Custom types must be registered with gob.Register.
+func (s *SeriesMixed) MarshalBinary() ([]byte, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	var e binaryEncoder
+	e.writeString(s.name)
+	e.writeUvarint(uint64(len(s.values)))
+	e.writeNils(len(s.values), func(i int) bool { return s.values[i] == nil })
+
+	if err := gobEncodeValues(&e.Buffer, s.values); err != nil {
+		return nil, err
+	}
+
+	return e.Bytes(), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (s *SeriesMixed) UnmarshalBinary(data []byte) error {
+
+	d := newBinaryDecoder(data)
+	name := d.readString()
+	n := d.readRows()
+	nils := d.readNils(n)
+	if d.err != nil {
+		return d.err
+	}
+
+	vals, nilCount, err := gobDecodeValues(d.r, nils)
+	if err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.name = name
+	s.values = vals
+	s.nilCount = nilCount
+	if s.valFormatter == nil {
+		s.valFormatter = DefaultValueFormatter
+	}
+	if s.isEqualFunc == nil {
+		s.isEqualFunc = DefaultIsEqualFunc
+	}
+
+	return nil
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+// The concrete type and values are encoded using the encoding/gob package. The concrete type must be registered with gob.Register.
+func (s *SeriesGeneric) MarshalBinary() ([]byte, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	var e binaryEncoder
+	e.writeString(s.name)
+	e.writeUvarint(uint64(len(s.values)))
+	e.writeNils(len(s.values), func(i int) bool { return s.values[i] == nil })
+
+	if err := gobEncodeValues(&e.Buffer, append([]interface{}{s.concreteType}, s.values...)); err != nil {
+		return nil, err
+	}
+
+	return e.Bytes(), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (s *SeriesGeneric) UnmarshalBinary(data []byte) error {
+
+	d := newBinaryDecoder(data)
+	name := d.readString()
+	n := d.readRows()
+	nils := d.readNils(n)
+	if d.err != nil {
+		return d.err
+	}
+
+	vals, nilCount, err := gobDecodeValues(d.r, append([]bool{false}, nils...))
+	if err != nil {
+		return err
+	}
+
+	if err := checkConcreteType(vals[0]); err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.name = name
+	s.concreteType = vals[0]
+	s.values = vals[1:]
+	s.nilCount = nilCount
+	if s.valFormatter == nil {
+		s.valFormatter = DefaultValueFormatter
+	}
+	if s.isEqualFunc == nil {
+		s.isEqualFunc = DefaultIsEqualFunc
+	}
+
+	return nil
+}
+
+func gobEncodeValues(w io.Writer, vals []interface{}) error {
+	enc := gob.NewEncoder(w)
+	for i := range vals {
+		if vals[i] == nil {
+			continue
+		}
+		if err := enc.Encode(&vals[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func gobDecodeValues(r io.Reader, nils []bool) ([]interface{}, int, error) {
+	dec := gob.NewDecoder(r)
+
+	vals := make([]interface{}, len(nils))
+	nilCount := 0
+	for i, isNil := range nils {
+		if isNil {
+			nilCount++
+			continue
+		}
+		if err := dec.Decode(&vals[i]); err != nil {
+			return nil, 0, err
+		}
+	}
+	return vals, nilCount, nil
+}
+
+// binaryEncoder writes the primitives of the native binary format.
+type binaryEncoder struct {
+	bytes.Buffer
+}
+
+func (e *binaryEncoder) writeUvarint(x uint64) {
+	var buf [binary.MaxVarintLen64]byte
+	e.Write(buf[:binary.PutUvarint(buf[:], x)])
+}
+
+func (e *binaryEncoder) writeVarint(x int64) {
+	var buf [binary.MaxVarintLen64]byte
+	e.Write(buf[:binary.PutVarint(buf[:], x)])
+}
+
+func (e *binaryEncoder) writeBytes(b []byte) {
+	e.writeUvarint(uint64(len(b)))
+	e.Write(b)
+}
+
+func (e *binaryEncoder) writeString(s string) {
+	e.writeUvarint(uint64(len(s)))
+	e.WriteString(s)
+}
+
+// writeNils writes a bitmap where a set bit signifies a nil value.
+func (e *binaryEncoder) writeNils(n int, isNil func(i int) bool) {
+	bitmap := make([]byte, (n+7)/8)
+	for i := 0; i < n; i++ {
+		if isNil(i) {
+			bitmap[i/8] |= 1 << uint(i%8)
+		}
+	}
+	e.Write(bitmap)
+}
+
+// binaryDecoder reads the primitives of the native binary format.
+// After the first error, all subsequent reads return zero values.
+type binaryDecoder struct {
+	r   *bytes.Reader
+	err error
+}
+
+func newBinaryDecoder(data []byte) *binaryDecoder {
+	return &binaryDecoder{r: bytes.NewReader(data)}
+}
+
+func (d *binaryDecoder) remaining() int {
+	return d.r.Len()
+}
+
+// done returns an error if a read failed or unread data remains.
+func (d *binaryDecoder) done() error {
+	if d.err == nil && d.remaining() != 0 {
+		d.err = ErrBinaryInvalid
+	}
+	return d.err
+}
+
+func (d *binaryDecoder) readByte() byte {
+	if d.err != nil {
+		return 0
+	}
+	b, err := d.r.ReadByte()
+	if err != nil {
+		d.err = ErrBinaryInvalid
+	}
+	return b
+}
+
+func (d *binaryDecoder) readN(n int) []byte {
+	if d.err != nil || n > d.remaining() {
+		d.err = ErrBinaryInvalid
+		return make([]byte, n)
+	}
+	b := make([]byte, n)
+	d.r.Read(b)
+	return b
+}
+
+func (d *binaryDecoder) readUvarint() uint64 {
+	if d.err != nil {
+		return 0
+	}
+	x, err := binary.ReadUvarint(d.r)
+	if err != nil {
+		d.err = ErrBinaryInvalid
+	}
+	return x
+}
+
+func (d *binaryDecoder) readVarint() int64 {
+	if d.err != nil {
+		return 0
+	}
+	x, err := binary.ReadVarint(d.r)
+	if err != nil {
+		d.err = ErrBinaryInvalid
+	}
+	return x
+}
+
+// readLen reads a length which can't exceed the remaining data.
+func (d *binaryDecoder) readLen() int {
+	x := d.readUvarint()
+	if d.err == nil && x > uint64(d.remaining()) {
+		d.err = ErrBinaryInvalid
+	}
+	if d.err != nil {
+		return 0
+	}
+	return int(x)
+}
+
+// readRows reads a row count. A row requires at least 1 bit (in the nil bitmap).
+func (d *binaryDecoder) readRows() int {
+	x := d.readUvarint()
+	if d.err == nil && x > 8*uint64(d.remaining()) {
+		d.err = ErrBinaryInvalid
+	}
+	if d.err != nil {
+		return 0
+	}
+	return int(x)
+}
+
+func (d *binaryDecoder) readBytes() []byte {
+	return d.readN(d.readLen())
+}
+
+func (d *binaryDecoder) readString() string {
+	return string(d.readBytes())
+}
+
+func (d *binaryDecoder) readNils(n int) []bool {
+	bitmap := d.readN((n + 7) / 8)
+	nils := make([]bool, n)
+	for i := range nils {
+		nils[i] = bitmap[i/8]&(1<<uint(i%8)) != 0
+	}
+	return nils
+}
@@ -5,6 +5,9 @@ package dataframe
 import (
 	"bytes"
 	"fmt"
+	"html"
+	"strconv"
+	"strings"
 
 	"github.com/olekukonko/tablewriter"
 )
@@ -29,6 +32,16 @@ type TableOptions struct {
 
 	// DontLock can be set to true if the DataFrame or Series should not be locked.
 	DontLock bool
+
+	// Display overrides the global display options (see SetDisplayOptions).
+	Display *DisplayOptions
+
+	// HTMLClass is used to set the class attribute of the table when generating HTML.
+	// The "dataframe" class is always set.
+	HTMLClass string
+
+	// DontEscape can be set to true if values should not be escaped when generating HTML, Markdown or LaTeX.
+	DontEscape bool
 }
 
 // Table will produce the DataFrame in a table.
@@ -39,143 +52,289 @@ func (df *DataFrame) Table(opts ...TableOptions) string {
 		defer df.lock.RUnlock()
 	}
 
+	seriess, rows, data := df.tableData("⋮", "…", opts...)
+	display := TableDisplayOptions(opts...)
+
+	headers := []string{""} // row header is blank
+	footers := []string{fmt.Sprintf("%dx%d", df.n, len(df.Series))}
+	for _, aSeries := range seriess {
+		if aSeries == nil {
+			headers = append(headers, "…")
+			footers = append(footers, "…")
+			continue
+		}
+		headers = append(headers, display.Truncate(aSeries.Name()))
+		footers = append(footers, aSeries.Type())
+	}
+
+	for i, row := range rows {
+		label := "⋮"
+		if row != -1 {
+			label = fmt.Sprintf("%d:", row)
+		}
+		data[i] = append([]string{label}, data[i]...)
+	}
+
+	var buf bytes.Buffer
+
+	table := tablewriter.NewWriter(&buf)
+	table.SetHeader(headers)
+	for _, v := range data {
+		table.Append(v)
+	}
+	table.SetFooter(footers)
+	table.SetAlignment(tablewriter.ALIGN_CENTER)
+
+	table.Render()
+
+	return buf.String()
+}
+
+// tableData returns the Series, row numbers and values (formatted using each Series' ValueToStringFormatter)
+// selected by the TableOptions. The display options are applied, with a nil Series signifying an ellipsis
+// column and a row of -1 signifying an ellipsis row. The values of the ellipsis row and column are set to
+// rowEllipsis and colEllipsis respectively.
+func (df *DataFrame) tableData(rowEllipsis, colEllipsis string, opts ...TableOptions) ([]Series, []int, [][]string) {
+
 	if len(opts) == 0 {
 		opts = append(opts, TableOptions{R: &Range{}})
 	} else if opts[0].R == nil {
 		opts[0].R = &Range{}
 	}
 
+	display := TableDisplayOptions(opts...)
+
 	columns := map[interface{}]struct{}{}
 	for _, v := range opts[0].Series {
 		columns[v] = struct{}{}
 	}
 
-	data := [][]string{}
-
-	headers := []string{""} // row header is blank
-	footers := []string{fmt.Sprintf("%dx%d", df.n, len(df.Series))}
+	selected := []Series{}
 	for idx, aSeries := range df.Series {
 		if len(columns) == 0 {
-			headers = append(headers, aSeries.Name())
-			footers = append(footers, aSeries.Type())
+			selected = append(selected, aSeries)
 		} else {
 			// Check idx
 			_, exists := columns[idx]
 			if exists {
-				headers = append(headers, aSeries.Name())
-				footers = append(footers, aSeries.Type())
+				selected = append(selected, aSeries)
 				continue
 			}
 
 			// Check series name
 			_, exists = columns[aSeries.Name()]
 			if exists {
-				headers = append(headers, aSeries.Name())
-				footers = append(footers, aSeries.Type())
+				selected = append(selected, aSeries)
 				continue
 			}
 		}
 	}
 
+	seriess := []Series{}
+	for _, idx := range display.Columns(len(selected)) {
+		if idx == -1 {
+			seriess = append(seriess, nil)
+		} else {
+			seriess = append(seriess, selected[idx])
+		}
+	}
+
+	rows := []int{}
+	data := [][]string{}
+
 	if df.n > 0 {
 		s, e, err := opts[0].R.Limits(df.n)
 		if err != nil {
 			panic(err)
 		}
 
-		for row := s; row <= e; row++ {
+		for _, row := range display.Rows(s, e) {
 
-			sVals := []string{fmt.Sprintf("%d:", row)}
-
-			for idx, aSeries := range df.Series {
-				if len(columns) == 0 {
-					sVals = append(sVals, aSeries.ValueString(row))
+			sVals := []string{}
+			for _, aSeries := range seriess {
+				if row == -1 {
+					sVals = append(sVals, rowEllipsis)
+				} else if aSeries == nil {
+					sVals = append(sVals, colEllipsis)
 				} else {
-					// Check idx
-					_, exists := columns[idx]
-					if exists {
-						sVals = append(sVals, aSeries.ValueString(row))
-						continue
-					}
-
-					// Check series name
-					_, exists = columns[aSeries.Name()]
-					if exists {
-						sVals = append(sVals, aSeries.ValueString(row))
-						continue
-					}
+					sVals = append(sVals, display.ValueString(aSeries, row))
 				}
 			}
 
+			rows = append(rows, row)
 			data = append(data, sVals)
 		}
 	}
 
-	var buf bytes.Buffer
+	return seriess, rows, data
+}
 
-	table := tablewriter.NewWriter(&buf)
-	table.SetHeader(headers)
-	for _, v := range data {
-		table.Append(v)
+// tableSeriesName returns the name of a Series for use as a header. A nil Series signifies an ellipsis column.
+func tableSeriesName(s Series, display DisplayOptions) string {
+	if s == nil {
+		return "..."
 	}
-	table.SetFooter(footers)
-	table.SetAlignment(tablewriter.ALIGN_CENTER)
+	return display.Truncate(s.Name())
+}
 
-	table.Render()
+// tableRowLabel returns the label of a row. A row of -1 signifies an ellipsis row.
+func tableRowLabel(row int) string {
+	if row == -1 {
+		return "..."
+	}
+	return strconv.Itoa(row)
+}
+
+// HTML will produce the DataFrame as a HTML table.
+func (df *DataFrame) HTML(opts ...TableOptions) string {
+
+	if len(opts) == 0 || !opts[0].DontLock {
+		df.lock.RLock()
+		defer df.lock.RUnlock()
+	}
+
+	seriess, rows, data := df.tableData("...", "...", opts...)
+	display := TableDisplayOptions(opts...)
+
+	escape := html.EscapeString
+	if len(opts) > 0 && opts[0].DontEscape {
+		escape = func(s string) string { return s }
+	}
+
+	class := "dataframe"
+	if len(opts) > 0 && opts[0].HTMLClass != "" {
+		class = class + " " + opts[0].HTMLClass
+	}
+
+	var buf bytes.Buffer
+
+	buf.WriteString(`<table class="` + html.EscapeString(class) + `">` + "\n")
+	buf.WriteString("  <thead>\n    <tr>\n      <th></th>\n")
+	for _, aSeries := range seriess {
+		buf.WriteString("      <th>" + escape(tableSeriesName(aSeries, display)) + "</th>\n")
+	}
+	buf.WriteString("    </tr>\n  </thead>\n  <tbody>\n")
+	for i, row := range rows {
+		buf.WriteString("    <tr>\n      <th>" + tableRowLabel(row) + "</th>\n")
+		for _, v := range data[i] {
+			buf.WriteString("      <td>" + escape(v) + "</td>\n")
+		}
+		buf.WriteString("    </tr>\n")
+	}
+	buf.WriteString("  </tbody>\n</table>\n")
 
 	return buf.String()
 }
 
-// String implements the fmt.Stringer interface. It does not lock the DataFrame.
-func (df *DataFrame) String() string {
+var markdownReplacer = strings.NewReplacer("|", "\\|", "\r\n", "<br>", "\n", "<br>")
 
-	if df.NRows() <= 6 {
-		return df.Table(TableOptions{DontLock: true})
+// Markdown will produce the DataFrame as a GitHub Flavored Markdown table.
+// Numeric Series are right-aligned.
+func (df *DataFrame) Markdown(opts ...TableOptions) string {
+
+	if len(opts) == 0 || !opts[0].DontLock {
+		df.lock.RLock()
+		defer df.lock.RUnlock()
 	}
 
-	idx := []int{0, 1, 2, df.n - 3, df.n - 2, df.n - 1}
+	seriess, rows, data := df.tableData("...", "...", opts...)
+	display := TableDisplayOptions(opts...)
 
-	data := [][]string{}
+	escape := markdownReplacer.Replace
+	if len(opts) > 0 && opts[0].DontEscape {
+		escape = func(s string) string { return s }
+	}
 
-	headers := []string{""} // row header is blank
-	footers := []string{fmt.Sprintf("%dx%d", df.n, len(df.Series))}
-	for _, aSeries := range df.Series {
-		headers = append(headers, aSeries.Name())
-		footers = append(footers, aSeries.Type())
+	var buf bytes.Buffer
+
+	buf.WriteString("|   |")
+	for _, aSeries := range seriess {
+		buf.WriteString(" " + escape(tableSeriesName(aSeries, display)) + " |")
 	}
+	buf.WriteString("\n|--:|")
+	for _, aSeries := range seriess {
+		switch aSeries.(type) {
+		case *SeriesFloat64, *SeriesInt64:
+			buf.WriteString("--:|")
+		default:
+			buf.WriteString("---|")
+		}
+	}
+	buf.WriteString("\n")
 
-	for j, row := range idx {
+	for i, row := range rows {
+		buf.WriteString("| " + tableRowLabel(row) + " |")
+		for _, v := range data[i] {
+			buf.WriteString(" " + escape(v) + " |")
+		}
+		buf.WriteString("\n")
+	}
 
-		if j == 3 {
-			sVals := []string{"⋮"}
+	return buf.String()
+}
 
-			for range df.Series {
-				sVals = append(sVals, "⋮")
-			}
+var latexReplacer = strings.NewReplacer(
+	`\`, `\textbackslash{}`,
+	`&`, `\&`,
+	`%`, `\%`,
+	`$`, `\$`,
+	`#`, `\#`,
+	`_`, `\_`,
+	`{`, `\{`,
+	`}`, `\}`,
+	`~`, `\textasciitilde{}`,
+	`^`, `\textasciicircum{}`,
+)
 
-			data = append(data, sVals)
-		}
+// LaTeX will produce the DataFrame as a LaTeX tabular environment.
+// Numeric Series are right-aligned.
+func (df *DataFrame) LaTeX(opts ...TableOptions) string {
 
-		sVals := []string{fmt.Sprintf("%d:", row)}
+	if len(opts) == 0 || !opts[0].DontLock {
+		df.lock.RLock()
+		defer df.lock.RUnlock()
+	}
 
-		for _, aSeries := range df.Series {
-			sVals = append(sVals, aSeries.ValueString(row))
-		}
+	seriess, rows, data := df.tableData("...", "...", opts...)
+	display := TableDisplayOptions(opts...)
 
-		data = append(data, sVals)
+	escape := latexReplacer.Replace
+	if len(opts) > 0 && opts[0].DontEscape {
+		escape = func(s string) string { return s }
 	}
 
 	var buf bytes.Buffer
 
-	table := tablewriter.NewWriter(&buf)
-	table.SetHeader(headers)
-	for _, v := range data {
-		table.Append(v)
+	buf.WriteString(`\begin{tabular}{r`)
+	for _, aSeries := range seriess {
+		switch aSeries.(type) {
+		case *SeriesFloat64, *SeriesInt64:
+			buf.WriteString("r")
+		default:
+			buf.WriteString("l")
+		}
 	}
-	table.SetFooter(footers)
-	table.SetAlignment(tablewriter.ALIGN_CENTER)
+	buf.WriteString("}\n\\hline\n")
 
-	table.Render()
+	for _, aSeries := range seriess {
+		buf.WriteString(" & " + escape(tableSeriesName(aSeries, display)))
+	}
+	buf.WriteString(" \\\\\n\\hline\n")
+
+	for i, row := range rows {
+		buf.WriteString(tableRowLabel(row))
+		for _, v := range data[i] {
+			buf.WriteString(" & " + escape(v))
+		}
+		buf.WriteString(" \\\\\n")
+	}
+	buf.WriteString("\\hline\n\\end{tabular}\n")
 
 	return buf.String()
 }
+
+// String implements the fmt.Stringer interface. It does not lock the DataFrame.
+func (df *DataFrame) String() string {
+	display := StringDisplayOptions()
+	return df.Table(TableOptions{DontLock: true, Display: &display})
+}
@@ -3,9 +3,12 @@
 package dataframe
 
 import (
+	"bytes"
 	"context"
+	"encoding/gob"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -224,3 +227,389 @@ func TestDfIsEqual(t *testing.T) {
 		t.Errorf("Df1: [%T] %s is not equal to Df2: [%T] %s\n", df1, df1.String(), df2, df2.String())
 	}
 }
+
+func TestBinary(t *testing.T) {
+	ctx := context.Background()
+
+	tm := time.Date(2020, 3, 1, 12, 30, 0, 0, time.FixedZone("AEDT", 11*60*60))
+
+	df1 := NewDataFrame(
+		NewSeriesInt64("day", nil, nil, 1, -2, 4),
+		NewSeriesFloat64("sales", nil, nil, 50.3, 23.4, 56.2),
+		NewSeriesString("name", nil, "a", nil, "", "d"),
+		NewSeriesTime("time", nil, tm, nil, tm.Add(time.Hour), tm),
+		NewSeriesDuration("duration", nil, time.Minute, -90*time.Second, nil, time.Hour),
+		NewSeriesMixed("mixed", nil, 1, "b", nil, tm),
+		NewSeriesGeneric("generic", "", nil, "x", nil, "y", "z"),
+	)
+
+	// Gob
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(df1); err != nil {
+		t.Fatalf("error encountered: %s\n", err)
+	}
+
+	df2 := NewDataFrame()
+	if err := gob.NewDecoder(&buf).Decode(df2); err != nil {
+		t.Fatalf("error encountered: %s\n", err)
+	}
+
+	eq, err := df1.IsEqual(ctx, df2, IsEqualOptions{CheckName: true})
+	if err != nil {
+		t.Errorf("error encountered: %s\n", err)
+	}
+
+	if !eq {
+		t.Errorf("Df1: [%T] %s is not equal to Df2: [%T] %s\n", df1, df1.String(), df2, df2.String())
+	}
+
+	for _, s := range df2.Series {
+		if n, _ := s.NilCount(); n != 1 {
+			t.Errorf("nil count not preserved for %s: %d", s.Name(), n)
+		}
+	}
+
+	// Corrupt data
+	data, _ := df1.MarshalBinary()
+	if err := NewDataFrame().UnmarshalBinary(data[:len(data)-3]); err == nil {
+		t.Errorf("expected error for corrupt data")
+	}
+}
+
+func TestTableFormats(t *testing.T) {
+
+	df := NewDataFrame(
+		NewSeriesInt64("a_b", nil, 1, nil, 3),
+		NewSeriesString("s", nil, "x|<y>&%", "z", "w"),
+	)
+
+	opts := TableOptions{R: &Range{End: &[]int{1}[0]}, HTMLClass: "wide"}
+
+	expectedHTML := `<table class="dataframe wide">
+  <thead>
+    <tr>
+      <th></th>
+      <th>a_b</th>
+      <th>s</th>
+    </tr>
+  </thead>
+  <tbody>
+    <tr>
+      <th>0</th>
+      <td>1</td>
+      <td>x|&lt;y&gt;&amp;%</td>
+    </tr>
+    <tr>
+      <th>1</th>
+      <td>NaN</td>
+      <td>z</td>
+    </tr>
+  </tbody>
+</table>
+`
+	if actual := df.HTML(opts); actual != expectedHTML {
+		t.Errorf("wrong val: expected: %v actual: %v", expectedHTML, actual)
+	}
+
+	expectedMarkdown := `|   | a_b | s |
+|--:|--:|---|
+| 0 | 1 | x\|<y>&% |
+| 1 | NaN | z |
+`
+	if actual := df.Markdown(opts); actual != expectedMarkdown {
+		t.Errorf("wrong val: expected: %v actual: %v", expectedMarkdown, actual)
+	}
+
+	expectedLaTeX := `\begin{tabular}{rl}
+\hline
+ & s \\
+\hline
+2 & w \\
+\hline
+\end{tabular}
+`
+	if actual := df.LaTeX(TableOptions{Series: []interface{}{"s"}, R: &Range{Start: &[]int{2}[0]}}); actual != expectedLaTeX {
+		t.Errorf("wrong val: expected: %v actual: %v", expectedLaTeX, actual)
+	}
+}
+
+func TestDisplayOptions(t *testing.T) {
+
+	df := NewDataFrame(
+		NewSeriesInt64("day", nil, 1, 2, 3, 4, 5),
+		NewSeriesFloat64("sales", nil, 50.3, nil, 56.2, 23.4, 1),
+		NewSeriesString("description", nil, "first", "second", "third", "fourth", "fifth"),
+	)
+
+	SetDisplayOptions(DisplayOptions{MaxRows: 3, MaxColumns: 2, MaxColWidth: 5, FloatPrecision: &[]int{2}[0], NilString: &[]string{"-"}[0]})
+	defer SetDisplayOptions(DisplayOptions{})
+
+	expected := `+-----+-------+---+--------+
+|     |  DAY  | … | DESC…  |
++-----+-------+---+--------+
+| 0:  |   1   | … | first  |
+| 1:  |   2   | … | seco…  |
+|  ⋮  |   ⋮   | ⋮ |   ⋮    |
+| 4:  |   5   | … | fifth  |
++-----+-------+---+--------+
+| 5X3 | INT64 | … | STRING |
++-----+-------+---+--------+`
+
+	if strings.TrimSpace(df.String()) != strings.TrimSpace(expected) {
+		t.Errorf("wrong val: expected: %v actual: %v", expected, df.String())
+	}
+
+	expectedSeries := `[ 50.30 - ... 1.00 ]`
+	if s := df.Series[1].(*SeriesFloat64).String(); s != expectedSeries {
+		t.Errorf("wrong val: expected: %v actual: %v", expectedSeries, s)
+	}
+
+	// Per-call display options override the global display options
+	expected = `+-----+---------+
+|     |  SALES  |
++-----+---------+
+| 0:  |  50.3   |
+| 1:  |   NaN   |
+| 2:  |  56.2   |
+| 3:  |  23.4   |
+| 4:  |    1    |
++-----+---------+
+| 5X1 | FLOAT64 |
++-----+---------+`
+
+	if s := df.Series[1].(*SeriesFloat64).Table(TableOptions{Display: &DisplayOptions{}}); strings.TrimSpace(s) != strings.TrimSpace(expected) {
+		t.Errorf("wrong val: expected: %v actual: %v", expected, s)
+	}
+}
+
+func TestNLargest(t *testing.T) {
+	ctx := context.Background()
+
+	s1 := NewSeriesInt64("score", nil, 10, 20, nil, 20, 5, 10)
+	s2 := NewSeriesString("name", nil, "a", "b", "c", "d", "e", "f")
+	df := NewDataFrame(s1, s2)
+
+	// Series
+	actual, err := NLargest(ctx, s1, 3)
+	if err != nil {
+		t.Fatalf("error encountered: %s\n", err)
+	}
+	expected := NewSeriesInt64("score", nil, 20, 20, 10)
+	if !cmp.Equal(actual, expected, cmpopts.IgnoreUnexported(SeriesInt64{})) {
+		t.Errorf("wrong val: expected: %v actual: %v", expected, actual)
+	}
+
+	actual, err = NSmallest(ctx, s1, 10)
+	if err != nil {
+		t.Fatalf("error encountered: %s\n", err)
+	}
+	expected = NewSeriesInt64("score", nil, 5, 10, 10, 20, 20)
+	if !cmp.Equal(actual, expected, cmpopts.IgnoreUnexported(SeriesInt64{})) {
+		t.Errorf("wrong val: expected: %v actual: %v", expected, actual)
+	}
+
+	// DataFrame
+	tests := []struct {
+		largest  bool
+		keys     []SortKey
+		expected *DataFrame
+	}{
+		{
+			true,
+			[]SortKey{{Key: "score"}},
+			NewDataFrame(NewSeriesInt64("score", nil, 20, 20), NewSeriesString("name", nil, "b", "d")),
+		},
+		{
+			true,
+			[]SortKey{{Key: "score"}, {Key: 1}},
+			NewDataFrame(NewSeriesInt64("score", nil, 20, 20), NewSeriesString("name", nil, "d", "b")),
+		},
+		{
+			false,
+			[]SortKey{{Key: "score"}, {Key: "name"}},
+			NewDataFrame(NewSeriesInt64("score", nil, 5, 10), NewSeriesString("name", nil, "e", "a")),
+		},
+	}
+
+	for i, tc := range tests {
+		var (
+			out interface{}
+			err error
+		)
+
+		if tc.largest {
+			out, err = NLargest(ctx, df, 2, NLargestOptions{Keys: tc.keys})
+		} else {
+			out, err = NSmallest(ctx, df, 2, NLargestOptions{Keys: tc.keys})
+		}
+		if err != nil {
+			t.Errorf("%d: error encountered: %s\n", i, err)
+			continue
+		}
+
+		if !cmp.Equal(out, tc.expected, cmpopts.IgnoreUnexported(DataFrame{}, SeriesInt64{}, SeriesString{})) {
+			t.Errorf("%d: wrong val: expected: %v actual: %v", i, tc.expected, out)
+		}
+	}
+
+	// Invalid keys
+	for _, key := range []interface{}{2, -1, 1.5, "unknown"} {
+		if _, err := NLargest(ctx, df, 2, NLargestOptions{Keys: []SortKey{{Key: key}}}); err == nil {
+			t.Errorf("expected error for key: %v", key)
+		}
+	}
+}
+
+func TestReplace(t *testing.T) {
+	ctx := context.Background()
+
+	df := NewDataFrame(
+		NewSeriesInt64("day", nil, 1, 2, nil, 2),
+		NewSeriesString("city", nil, "N.Y.", "new york", nil, "LA"),
+	)
+
+	// Exact replacements applied to each Series
+	out, err := Replace(ctx, df, map[interface{}]interface{}{2: 20, "LA": "Los Angeles"})
+	if err != nil {
+		t.Fatalf("error encountered: %s\n", err)
+	}
+
+	expected := NewDataFrame(
+		NewSeriesInt64("day", nil, 1, 20, nil, 20),
+		NewSeriesString("city", nil, "N.Y.", "new york", nil, "Los Angeles"),
+	)
+
+	if !cmp.Equal(out, expected, cmpopts.IgnoreUnexported(DataFrame{}, SeriesInt64{}, SeriesString{})) {
+		t.Errorf("wrong val: expected: %v actual: %v", expected, out)
+	}
+
+	// Regular expressions
+	_, err = Replace(ctx, df.Series[1], map[interface{}]interface{}{`(?i)^(n\.y\.|new york)$`: "New York", nil: "unknown"}, ReplaceOptions{Regex: true, InPlace: true})
+	if err != nil {
+		t.Fatalf("error encountered: %s\n", err)
+	}
+
+	expectedSeries := NewSeriesString("city", nil, "New York", "New York", "unknown", "LA")
+	if !cmp.Equal(df.Series[1], expectedSeries, cmpopts.IgnoreUnexported(SeriesString{})) {
+		t.Errorf("wrong val: expected: %v actual: %v", expectedSeries, df.Series[1])
+	}
+
+	// Invalid new values are detected before anything is modified
+	df = NewDataFrame(
+		NewSeriesString("city", nil, "Paris", nil),
+		NewSeriesInt64("day", nil, 1, nil),
+	)
+
+	_, err = Replace(ctx, df, map[interface{}]interface{}{nil: "unknown"}, ReplaceOptions{InPlace: true})
+	if err == nil {
+		t.Errorf("expected error for invalid new value")
+	}
+
+	if df.Series[0].Value(1) != nil {
+		t.Errorf("wrong val: expected: %v actual: %v", nil, df.Series[0].Value(1))
+	}
+
+	_, err = Replace(ctx, df.Series[0], map[interface{}]interface{}{"^P": nil}, ReplaceOptions{Regex: true})
+	if err == nil {
+		t.Errorf("expected error for non-string regex replacement")
+	}
+
+	// Numeric keys are converted to the type of the Series
+	out, err = Replace(ctx, NewSeriesFloat64("x", nil, 1, 2.5, nil), map[interface{}]interface{}{1: 10.0, 2.5: 25.0})
+	if err != nil {
+		t.Fatalf("error encountered: %s\n", err)
+	}
+
+	expectedFloat := NewSeriesFloat64("x", nil, 10.0, 25.0, nil)
+	if !cmp.Equal(out, expectedFloat, cmpopts.EquateNaNs(), cmpopts.IgnoreUnexported(SeriesFloat64{})) {
+		t.Errorf("wrong val: expected: %v actual: %v", expectedFloat, out)
+	}
+}
+
+func TestMap(t *testing.T) {
+	ctx := context.Background()
+
+	s := NewSeriesString("size", nil, "S", "M", nil, "XL")
+
+	// map
+	out, err := Map(ctx, s, map[interface{}]interface{}{"S": 1, "M": 2, "L": 3})
+	if err != nil {
+		t.Fatalf("error encountered: %s\n", err)
+	}
+
+	expected := NewSeriesInt64("size", nil, 1, 2, nil, nil)
+	if !cmp.Equal(out, expected, cmpopts.IgnoreUnexported(SeriesInt64{})) {
+		t.Errorf("wrong val: expected: %v actual: %v", expected, out)
+	}
+
+	// MapFn
+	out, err = Map(ctx, s, MapFn(func(val interface{}) interface{} {
+		return float64(len(val.(string))) / 2
+	}))
+	if err != nil {
+		t.Fatalf("error encountered: %s\n", err)
+	}
+
+	expectedFloat := NewSeriesFloat64("size", nil, 0.5, 0.5, nil, 1.0)
+	if !cmp.Equal(out, expectedFloat, cmpopts.EquateNaNs(), cmpopts.IgnoreUnexported(SeriesFloat64{})) {
+		t.Errorf("wrong val: expected: %v actual: %v", expectedFloat, out)
+	}
+
+	// Numeric keys are converted to the type of the Series
+	out, err = Map(ctx, NewSeriesFloat64("x", nil, 1, 2.5, nil), map[interface{}]interface{}{1: "one", 2.5: "two and a half"})
+	if err != nil {
+		t.Fatalf("error encountered: %s\n", err)
+	}
+
+	expectedString := NewSeriesString("x", nil, "one", "two and a half", nil)
+	if !cmp.Equal(out, expectedString, cmpopts.IgnoreUnexported(SeriesString{})) {
+		t.Errorf("wrong val: expected: %v actual: %v", expectedString, out)
+	}
+}
+
+func TestIsIn(t *testing.T) {
+	ctx := context.Background()
+
+	df := NewDataFrame(
+		NewSeriesInt64("day", nil, 1, 2, nil, 3),
+		NewSeriesString("city", nil, "Paris", "Rome", "Oslo", nil),
+	)
+
+	out, err := IsIn(ctx, df, []interface{}{1, 3, "Rome", nil})
+	if err != nil {
+		t.Fatalf("error encountered: %s\n", err)
+	}
+
+	expected := NewDataFrame(
+		NewSeriesInt64("day", nil, 1, 0, 1, 1),
+		NewSeriesInt64("city", nil, 0, 1, 0, 1),
+	)
+
+	if !cmp.Equal(out, expected, cmpopts.IgnoreUnexported(DataFrame{}, SeriesInt64{})) {
+		t.Errorf("wrong val: expected: %v actual: %v", expected, out)
+	}
+
+	if s := out.(*DataFrame).Series[1].ValueString(1); s != "true" {
+		t.Errorf("wrong val: expected: %v actual: %v", "true", s)
+	}
+
+	// Numeric values are converted to the type of the Series
+	tests := []struct {
+		s        Series
+		expected Series
+	}{
+		{NewSeriesFloat64("x", nil, 1, 2.5, 3), NewSeriesInt64("x", nil, 1, 1, 0)},
+		{NewSeriesInt64("x", nil, 1, 2, 3), NewSeriesInt64("x", nil, 1, 0, 0)},
+	}
+
+	for i, tc := range tests {
+		out, err := IsIn(ctx, tc.s, []interface{}{1, 2.5, int64(4)})
+		if err != nil {
+			t.Fatalf("%d: error encountered: %s\n", i, err)
+		}
+
+		if !cmp.Equal(out, tc.expected, cmpopts.IgnoreUnexported(SeriesInt64{})) {
+			t.Errorf("%d: wrong val: expected: %v actual: %v", i, tc.expected, out)
+		}
+	}
+}
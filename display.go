@@ -0,0 +1,153 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package dataframe
+
+import (
+	"fmt"
+	"sync"
+	"unicode/utf8"
+)
+
+// DisplayOptions configures how DataFrames and Series are displayed by Table and String.
+//
+// Example:
+//
+//  dataframe.SetDisplayOptions(dataframe.DisplayOptions{MaxRows: 10, FloatPrecision: &[]int{2}[0]})
+//
+type DisplayOptions struct {
+
+	// MaxRows is the maximum number of rows displayed. When exceeded, the first and last rows are
+	// displayed, separated by an ellipsis row.
+	// When 0, Table displays all rows and String displays at most 6 rows.
+	MaxRows int
+
+	// MaxColumns is the maximum number of Series displayed. When exceeded, the first and last Series are
+	// displayed, separated by an ellipsis column. When 0, all Series are displayed.
+	//
+	// NOTE: This option only applies to DataFrames.
+	MaxColumns int
+
+	// MaxColWidth is the maximum number of characters of a value or Series name. Longer values are
+	// truncated with an ellipsis. When 0, values are not truncated.
+	MaxColWidth int
+
+	// FloatPrecision sets the number of decimal places of float and complex values.
+	// When nil, the Series' ValueToStringFormatter is used.
+	FloatPrecision *int
+
+	// NilString sets how nil values are displayed.
+	// When nil, the Series' ValueToStringFormatter is used.
+	NilString *string
+}
+
+// defaultStringMaxRows is the maximum number of rows displayed by String when MaxRows is not set.
+const defaultStringMaxRows = 6
+
+var (
+	displayOptionsLock sync.RWMutex
+	displayOptions     DisplayOptions
+)
+
+// SetDisplayOptions sets the global display options used by Table and String.
+// Table can override them using TableOptions.
+func SetDisplayOptions(opts DisplayOptions) {
+	displayOptionsLock.Lock()
+	defer displayOptionsLock.Unlock()
+	displayOptions = opts
+}
+
+// GetDisplayOptions returns the global display options.
+func GetDisplayOptions() DisplayOptions {
+	displayOptionsLock.RLock()
+	defer displayOptionsLock.RUnlock()
+	return displayOptions
+}
+
+// StringDisplayOptions returns the global display options that apply to String.
+func StringDisplayOptions() DisplayOptions {
+	opts := GetDisplayOptions()
+	if opts.MaxRows <= 0 {
+		opts.MaxRows = defaultStringMaxRows
+	}
+	return opts
+}
+
+// TableDisplayOptions returns the display options that apply to Table.
+// They are the global display options unless overridden by opts.
+func TableDisplayOptions(opts ...TableOptions) DisplayOptions {
+	if len(opts) > 0 && opts[0].Display != nil {
+		return *opts[0].Display
+	}
+	return GetDisplayOptions()
+}
+
+// Rows returns the rows (from start to end inclusive) that should be displayed.
+// A row of -1 signifies the position of the ellipsis row.
+func (o DisplayOptions) Rows(start, end int) []int {
+	return truncatedIndexes(start, end, o.MaxRows)
+}
+
+// Columns returns the indexes of the n Series that should be displayed.
+// An index of -1 signifies the position of the ellipsis column.
+func (o DisplayOptions) Columns(n int) []int {
+	return truncatedIndexes(0, n-1, o.MaxColumns)
+}
+
+func truncatedIndexes(start, end, max int) []int {
+	out := []int{}
+
+	if max <= 0 || end-start+1 <= max {
+		for i := start; i <= end; i++ {
+			out = append(out, i)
+		}
+		return out
+	}
+
+	head := (max + 1) / 2
+	tail := max - head
+
+	for i := start; i < start+head; i++ {
+		out = append(out, i)
+	}
+	out = append(out, -1)
+	for i := end - tail + 1; i <= end; i++ {
+		out = append(out, i)
+	}
+	return out
+}
+
+// ValueString returns the string representation of a row of s after applying the display options.
+// It does not lock the Series.
+func (o DisplayOptions) ValueString(s Series, row int) string {
+
+	var str string
+
+	val := s.Value(row, dontLock)
+	if val == nil && o.NilString != nil {
+		str = *o.NilString
+	} else if o.FloatPrecision != nil {
+		switch v := val.(type) {
+		case float64, float32, complex128, complex64:
+			str = fmt.Sprintf("%.*f", *o.FloatPrecision, v)
+		default:
+			str = s.ValueString(row, dontLock)
+		}
+	} else {
+		str = s.ValueString(row, dontLock)
+	}
+
+	return o.Truncate(str)
+}
+
+// Truncate shortens str to MaxColWidth characters.
+func (o DisplayOptions) Truncate(str string) string {
+	if o.MaxColWidth <= 0 || utf8.RuneCountInString(str) <= o.MaxColWidth {
+		return str
+	}
+
+	runes := []rune(str)
+	if o.MaxColWidth == 1 {
+		return "…"
+	}
+	return string(runes[:o.MaxColWidth-1]) + "…"
+}
@@ -0,0 +1,539 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package exports
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	flatbuffers "github.com/google/flatbuffers/go"
+	dataframe "github.com/rocketlaunchr/dataframe-go"
+)
+
+// ArrowExportOptions contains options for ExportToArrow function.
+type ArrowExportOptions struct {
+
+	// Range is used to export a subset of rows from the Dataframe.
+	Range dataframe.Range
+
+	// FileFormat will write the Arrow IPC file (random access) format instead of the streaming format.
+	//
+	// See: https://arrow.apache.org/docs/format/Columnar.html#ipc-file-format
+	FileFormat bool
+
+	// BatchSize sets the maximum number of rows per record batch.
+	// When not set, all rows are written in a single record batch.
+	BatchSize int
+
+	// Dictionary is a list of SeriesString names that should be dictionary encoded.
+	// It is recommended for categorical data that contains many repeated values.
+	Dictionary []string
+}
+
+// Arrow metadata constants.
+//
+// See: https://github.com/apache/arrow/tree/master/format
+const (
+	arrowMetadataV5 = 4
+
+	arrowHeaderSchema          = 1
+	arrowHeaderDictionaryBatch = 2
+	arrowHeaderRecordBatch     = 3
+
+	arrowTypeInt           = 2
+	arrowTypeFloatingPoint = 3
+	arrowTypeUtf8          = 5
+	arrowTypeTimestamp     = 10
+
+	arrowPrecisionDouble = 2
+	arrowUnitNanosecond  = 3
+)
+
+var arrowMagic = []byte("ARROW1")
+
+type arrowColumn struct {
+	s    dataframe.Series
+	typ  byte
+	tz   string
+	dict *arrowDictionary
+}
+
+type arrowDictionary struct {
+	id      int64
+	indices map[string]int32
+	values  []string
+}
+
+type arrowBlock struct {
+	offset     int64
+	metaLength int32
+	bodyLength int64
+}
+
+// arrowWriter keeps track of the position of the underlying writer.
+type arrowWriter struct {
+	w   io.Writer
+	pos int64
+}
+
+func (aw *arrowWriter) Write(p []byte) (int, error) {
+	n, err := aw.w.Write(p)
+	aw.pos += int64(n)
+	return n, err
+}
+
+// ExportToArrow exports a Dataframe to the Apache Arrow IPC format.
+//
+// SeriesFloat64 is mapped to a float64 field, SeriesInt64 to an int64 field and SeriesTime to a timestamp field (nanoseconds)
+// with the time zone of the first non-nil value. All other Series are mapped to a utf8 field using ValueString.
+// nil values are preserved.
+//
+// The underlying buffer of a SeriesFloat64 is written directly without being copied.
+//
+// See: https://arrow.apache.org/docs/format/Columnar.html#serialization-and-interprocess-communication-ipc
+func ExportToArrow(ctx context.Context, w io.Writer, df *dataframe.DataFrame, options ...ArrowExportOptions) error {
+
+	df.Lock()
+	defer df.Unlock()
+
+	var (
+		r          dataframe.Range
+		fileFormat bool
+		batchSize  int
+		dictionary = map[string]struct{}{}
+	)
+
+	if len(options) > 0 {
+		r = options[0].Range
+		fileFormat = options[0].FileFormat
+		batchSize = options[0].BatchSize
+		for _, name := range options[0].Dictionary {
+			dictionary[name] = struct{}{}
+		}
+	}
+
+	nRows := df.NRows(dataframe.DontLock)
+
+	start, end := 0, -1
+	if nRows > 0 {
+		var err error
+		start, end, err = r.Limits(nRows)
+		if err != nil {
+			return err
+		}
+	}
+
+	if batchSize <= 0 {
+		batchSize = end - start + 1
+	}
+
+	// Determine the arrow type of each Series
+	cols := []*arrowColumn{}
+	dicts := []*arrowColumn{}
+
+	for _, aSeries := range df.Series {
+		col := &arrowColumn{s: aSeries}
+		name := aSeries.Name(dataframe.DontLock)
+
+		switch s := aSeries.(type) {
+		case *dataframe.SeriesFloat64:
+			col.typ = arrowTypeFloatingPoint
+		case *dataframe.SeriesInt64:
+			col.typ = arrowTypeInt
+		case *dataframe.SeriesTime:
+			col.typ = arrowTypeTimestamp
+			col.tz = "UTC"
+			for row := start; row <= end; row++ {
+				if t := s.Values[row]; t != nil {
+					if tz := t.Location().String(); tz == "" {
+						col.tz = t.Format("-07:00")
+					} else if tz != "Local" {
+						col.tz = tz
+					}
+					break
+				}
+			}
+		default:
+			col.typ = arrowTypeUtf8
+			if _, exists := dictionary[name]; exists {
+				if _, ok := aSeries.(*dataframe.SeriesString); !ok {
+					return fmt.Errorf("dictionary encoding requires a SeriesString: %s", name)
+				}
+				col.dict = &arrowDictionary{id: int64(len(dicts)), indices: map[string]int32{}}
+				dicts = append(dicts, col)
+			}
+		}
+		cols = append(cols, col)
+	}
+
+	aw := &arrowWriter{w: w}
+
+	if fileFormat {
+		if _, err := aw.Write([]byte("ARROW1\x00\x00")); err != nil {
+			return err
+		}
+	}
+
+	b := flatbuffers.NewBuilder(1024)
+
+	// Schema
+	b.Finish(arrowMessage(b, arrowHeaderSchema, arrowSchema(b, cols), 0))
+	if _, err := arrowWriteMessage(aw, b.FinishedBytes(), nil); err != nil {
+		return err
+	}
+
+	// Dictionaries
+	dictBlocks := []arrowBlock{}
+
+	for _, col := range dicts {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		for row := start; row <= end; row++ {
+			if v := col.s.Value(row, dataframe.DontLock); v != nil {
+				if _, exists := col.dict.indices[v.(string)]; !exists {
+					col.dict.indices[v.(string)] = int32(len(col.dict.values))
+					col.dict.values = append(col.dict.values, v.(string))
+				}
+			}
+		}
+
+		offsets, data := arrowUtf8(len(col.dict.values), func(i int) (string, bool) {
+			return col.dict.values[i], true
+		})
+		nodes := [][2]int64{{int64(len(col.dict.values)), 0}}
+		body := [][]byte{nil, offsets, data}
+
+		b.Reset()
+		rb := arrowRecordBatch(b, int64(len(col.dict.values)), nodes, body)
+		b.StartObject(3)
+		b.PrependInt64Slot(0, col.dict.id, 0)
+		b.PrependUOffsetTSlot(1, rb, 0)
+		b.Finish(arrowMessage(b, arrowHeaderDictionaryBatch, b.EndObject(), arrowBodyLength(body)))
+
+		block, err := arrowWriteMessage(aw, b.FinishedBytes(), body)
+		if err != nil {
+			return err
+		}
+		dictBlocks = append(dictBlocks, block)
+	}
+
+	// Record batches
+	batchBlocks := []arrowBlock{}
+
+	for bs := start; bs <= end; bs = bs + batchSize {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		be := bs + batchSize - 1
+		if be > end {
+			be = end
+		}
+
+		nodes := [][2]int64{}
+		body := [][]byte{}
+		for _, col := range cols {
+			nullCount, bufs := col.buffers(bs, be)
+			nodes = append(nodes, [2]int64{int64(be - bs + 1), int64(nullCount)})
+			body = append(body, bufs...)
+		}
+
+		b.Reset()
+		rb := arrowRecordBatch(b, int64(be-bs+1), nodes, body)
+		b.Finish(arrowMessage(b, arrowHeaderRecordBatch, rb, arrowBodyLength(body)))
+
+		block, err := arrowWriteMessage(aw, b.FinishedBytes(), body)
+		if err != nil {
+			return err
+		}
+		batchBlocks = append(batchBlocks, block)
+	}
+
+	// End-of-stream marker
+	if _, err := aw.Write([]byte{0xFF, 0xFF, 0xFF, 0xFF, 0, 0, 0, 0}); err != nil {
+		return err
+	}
+
+	if !fileFormat {
+		return nil
+	}
+
+	// Footer
+	b.Reset()
+	schema := arrowSchema(b, cols)
+	dictVec := arrowBlocks(b, dictBlocks)
+	batchVec := arrowBlocks(b, batchBlocks)
+	b.StartObject(5)
+	b.PrependInt16Slot(0, arrowMetadataV5, 0)
+	b.PrependUOffsetTSlot(1, schema, 0)
+	b.PrependUOffsetTSlot(2, dictVec, 0)
+	b.PrependUOffsetTSlot(3, batchVec, 0)
+	b.Finish(b.EndObject())
+
+	footer := b.FinishedBytes()
+	if _, err := aw.Write(footer); err != nil {
+		return err
+	}
+
+	size := make([]byte, 4)
+	binary.LittleEndian.PutUint32(size, uint32(len(footer)))
+	if _, err := aw.Write(size); err != nil {
+		return err
+	}
+
+	_, err := aw.Write(arrowMagic)
+	return err
+}
+
+// buffers returns the null count and the arrow buffers for the rows between start and end (inclusive).
+func (col *arrowColumn) buffers(start, end int) (int, [][]byte) {
+
+	n := end - start + 1
+
+	// Validity bitmap. It is omitted when there are no nil values.
+	var nullCount int
+	validity := make([]byte, (n+7)/8)
+	for i := 0; i < n; i++ {
+		if col.s.Value(start+i, dataframe.DontLock) == nil {
+			nullCount++
+		} else {
+			validity[i/8] |= 1 << uint(i%8)
+		}
+	}
+	if nullCount == 0 {
+		validity = nil
+	}
+
+	switch s := col.s.(type) {
+	case *dataframe.SeriesFloat64:
+		// nil values are stored as NaN, which is acceptable for null slots
+		return nullCount, [][]byte{validity, float64Bytes(s.Values[start : end+1])}
+	case *dataframe.SeriesInt64:
+		data := make([]byte, 8*n)
+		for i := 0; i < n; i++ {
+			if v := s.Value(start+i, dataframe.DontLock); v != nil {
+				binary.LittleEndian.PutUint64(data[8*i:], uint64(v.(int64)))
+			}
+		}
+		return nullCount, [][]byte{validity, data}
+	case *dataframe.SeriesTime:
+		data := make([]byte, 8*n)
+		for i := 0; i < n; i++ {
+			if t := s.Values[start+i]; t != nil {
+				binary.LittleEndian.PutUint64(data[8*i:], uint64(t.UnixNano()))
+			}
+		}
+		return nullCount, [][]byte{validity, data}
+	}
+
+	if col.dict != nil {
+		data := make([]byte, 4*n)
+		for i := 0; i < n; i++ {
+			if v := col.s.Value(start+i, dataframe.DontLock); v != nil {
+				binary.LittleEndian.PutUint32(data[4*i:], uint32(col.dict.indices[v.(string)]))
+			}
+		}
+		return nullCount, [][]byte{validity, data}
+	}
+
+	offsets, data := arrowUtf8(n, func(i int) (string, bool) {
+		if col.s.Value(start+i, dataframe.DontLock) == nil {
+			return "", false
+		}
+		if s, ok := col.s.(*dataframe.SeriesString); ok {
+			return s.Value(start+i, dataframe.DontLock).(string), true
+		}
+		return col.s.ValueString(start+i, dataframe.DontLock), true
+	})
+	return nullCount, [][]byte{validity, offsets, data}
+}
+
+// arrowUtf8 returns the offsets and data buffers of a utf8 array.
+func arrowUtf8(n int, val func(i int) (string, bool)) ([]byte, []byte) {
+	offsets := make([]byte, 4*(n+1))
+	data := []byte{}
+	for i := 0; i < n; i++ {
+		if v, ok := val(i); ok {
+			data = append(data, v...)
+		}
+		binary.LittleEndian.PutUint32(offsets[4*(i+1):], uint32(len(data)))
+	}
+	return offsets, data
+}
+
+func arrowPadding(n int64) int64 {
+	return (8 - n%8) % 8
+}
+
+func arrowBodyLength(body [][]byte) int64 {
+	var l int64
+	for _, buf := range body {
+		l = l + int64(len(buf)) + arrowPadding(int64(len(buf)))
+	}
+	return l
+}
+
+// arrowWriteMessage writes an encapsulated message.
+//
+// See: https://arrow.apache.org/docs/format/Columnar.html#encapsulated-message-format
+func arrowWriteMessage(aw *arrowWriter, meta []byte, body [][]byte) (arrowBlock, error) {
+
+	block := arrowBlock{offset: aw.pos}
+
+	// The metadata is padded so that the body starts on an 8-byte boundary
+	metaLength := int64(len(meta)) + arrowPadding(int64(len(meta)))
+
+	prefix := make([]byte, 8)
+	binary.LittleEndian.PutUint32(prefix, 0xFFFFFFFF)
+	binary.LittleEndian.PutUint32(prefix[4:], uint32(metaLength))
+	if _, err := aw.Write(prefix); err != nil {
+		return block, err
+	}
+	if _, err := aw.Write(meta); err != nil {
+		return block, err
+	}
+	if _, err := aw.Write(make([]byte, arrowPadding(int64(len(meta))))); err != nil {
+		return block, err
+	}
+
+	bodyStart := aw.pos
+	for _, buf := range body {
+		if _, err := aw.Write(buf); err != nil {
+			return block, err
+		}
+		if _, err := aw.Write(make([]byte, arrowPadding(int64(len(buf))))); err != nil {
+			return block, err
+		}
+	}
+
+	block.metaLength = int32(8 + metaLength)
+	block.bodyLength = aw.pos - bodyStart
+	return block, nil
+}
+
+func arrowMessage(b *flatbuffers.Builder, headerType byte, header flatbuffers.UOffsetT, bodyLength int64) flatbuffers.UOffsetT {
+	b.StartObject(5)
+	b.PrependInt16Slot(0, arrowMetadataV5, 0)
+	b.PrependByteSlot(1, headerType, 0)
+	b.PrependUOffsetTSlot(2, header, 0)
+	b.PrependInt64Slot(3, bodyLength, 0)
+	return b.EndObject()
+}
+
+func arrowSchema(b *flatbuffers.Builder, cols []*arrowColumn) flatbuffers.UOffsetT {
+
+	fields := []flatbuffers.UOffsetT{}
+
+	for _, col := range cols {
+		name := b.CreateString(col.s.Name(dataframe.DontLock))
+
+		var typ flatbuffers.UOffsetT
+		switch col.typ {
+		case arrowTypeInt:
+			typ = arrowInt(b, 64)
+		case arrowTypeFloatingPoint:
+			b.StartObject(1)
+			b.PrependInt16Slot(0, arrowPrecisionDouble, 0)
+			typ = b.EndObject()
+		case arrowTypeTimestamp:
+			tz := b.CreateString(col.tz)
+			b.StartObject(2)
+			b.PrependInt16Slot(0, arrowUnitNanosecond, 0)
+			b.PrependUOffsetTSlot(1, tz, 0)
+			typ = b.EndObject()
+		default:
+			b.StartObject(0)
+			typ = b.EndObject()
+		}
+
+		var dict flatbuffers.UOffsetT
+		if col.dict != nil {
+			indexType := arrowInt(b, 32)
+			b.StartObject(4)
+			b.PrependInt64Slot(0, col.dict.id, 0)
+			b.PrependUOffsetTSlot(1, indexType, 0)
+			dict = b.EndObject()
+		}
+
+		b.StartVector(4, 0, 4)
+		children := b.EndVector(0)
+
+		b.StartObject(7)
+		b.PrependUOffsetTSlot(0, name, 0)
+		b.PrependBoolSlot(1, true, false)
+		b.PrependByteSlot(2, col.typ, 0)
+		b.PrependUOffsetTSlot(3, typ, 0)
+		if col.dict != nil {
+			b.PrependUOffsetTSlot(4, dict, 0)
+		}
+		b.PrependUOffsetTSlot(5, children, 0)
+		fields = append(fields, b.EndObject())
+	}
+
+	b.StartVector(4, len(fields), 4)
+	for i := len(fields) - 1; i >= 0; i-- {
+		b.PrependUOffsetT(fields[i])
+	}
+	fieldVec := b.EndVector(len(fields))
+
+	b.StartObject(4)
+	b.PrependUOffsetTSlot(1, fieldVec, 0)
+	return b.EndObject()
+}
+
+func arrowInt(b *flatbuffers.Builder, bitWidth int32) flatbuffers.UOffsetT {
+	b.StartObject(2)
+	b.PrependInt32Slot(0, bitWidth, 0)
+	b.PrependBoolSlot(1, true, false)
+	return b.EndObject()
+}
+
+func arrowRecordBatch(b *flatbuffers.Builder, length int64, nodes [][2]int64, body [][]byte) flatbuffers.UOffsetT {
+
+	// FieldNode structs
+	b.StartVector(16, len(nodes), 8)
+	for i := len(nodes) - 1; i >= 0; i-- {
+		b.Prep(8, 16)
+		b.PrependInt64(nodes[i][1]) // null_count
+		b.PrependInt64(nodes[i][0]) // length
+	}
+	nodeVec := b.EndVector(len(nodes))
+
+	// Buffer structs
+	offsets := make([]int64, len(body))
+	var offset int64
+	for i, buf := range body {
+		offsets[i] = offset
+		offset = offset + int64(len(buf)) + arrowPadding(int64(len(buf)))
+	}
+
+	b.StartVector(16, len(body), 8)
+	for i := len(body) - 1; i >= 0; i-- {
+		b.Prep(8, 16)
+		b.PrependInt64(int64(len(body[i]))) // length
+		b.PrependInt64(offsets[i])          // offset
+	}
+	bufVec := b.EndVector(len(body))
+
+	b.StartObject(4)
+	b.PrependInt64Slot(0, length, 0)
+	b.PrependUOffsetTSlot(1, nodeVec, 0)
+	b.PrependUOffsetTSlot(2, bufVec, 0)
+	return b.EndObject()
+}
+
+func arrowBlocks(b *flatbuffers.Builder, blocks []arrowBlock) flatbuffers.UOffsetT {
+	b.StartVector(24, len(blocks), 8)
+	for i := len(blocks) - 1; i >= 0; i-- {
+		b.Prep(8, 24)
+		b.PrependInt64(blocks[i].bodyLength)
+		b.Pad(4)
+		b.PrependInt32(blocks[i].metaLength)
+		b.PrependInt64(blocks[i].offset)
+	}
+	return b.EndVector(len(blocks))
+}
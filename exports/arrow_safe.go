@@ -0,0 +1,19 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+// +build js appengine safe !386,!amd64,!amd64p32,!arm,!arm64,!mipsle,!mips64le,!mips64p32le,!ppc64le,!riscv64,!wasm
+
+package exports
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// float64Bytes returns the little-endian encoding of f.
+func float64Bytes(f []float64) []byte {
+	b := make([]byte, 8*len(f))
+	for i, v := range f {
+		binary.LittleEndian.PutUint64(b[8*i:], math.Float64bits(v))
+	}
+	return b
+}
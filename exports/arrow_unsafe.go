@@ -0,0 +1,26 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+// +build !js,!appengine,!safe
+// +build 386 amd64 amd64p32 arm arm64 mipsle mips64le mips64p32le ppc64le riscv64 wasm
+
+package exports
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+// float64Bytes returns the underlying bytes of f without copying.
+// It is only built for little-endian architectures.
+func float64Bytes(f []float64) []byte {
+	if len(f) == 0 {
+		return nil
+	}
+
+	var b []byte
+	hdr := (*reflect.SliceHeader)(unsafe.Pointer(&b))
+	hdr.Data = uintptr(unsafe.Pointer(&f[0]))
+	hdr.Len = 8 * len(f)
+	hdr.Cap = 8 * len(f)
+	return b
+}
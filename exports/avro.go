@@ -0,0 +1,420 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package exports
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/linkedin/goavro/v2"
+	dataframe "github.com/rocketlaunchr/dataframe-go"
+)
+
+// AvroCompression sets the compression codec of an Avro Object Container File.
+type AvroCompression string
+
+const (
+	// AvroNull means no compression.
+	AvroNull AvroCompression = goavro.CompressionNullLabel
+	// AvroDeflate uses the deflate algorithm.
+	AvroDeflate AvroCompression = goavro.CompressionDeflateLabel
+	// AvroSnappy uses the snappy algorithm.
+	AvroSnappy AvroCompression = goavro.CompressionSnappyLabel
+)
+
+// AvroExportOptions contains options for ExportToAvro function.
+type AvroExportOptions struct {
+
+	// Range is used to export a subset of rows from the Dataframe.
+	Range dataframe.Range
+
+	// Schema can be set to provide the Avro schema (in JSON). It must be a record type.
+	// The record's field names must match the Series names.
+	//
+	// When not set, a schema is generated where each field is a union of null and:
+	// double for SeriesFloat64, long for SeriesInt64, long (timestamp-micros) for SeriesTime
+	// and string for all other Series. Invalid characters in Series names are replaced by '_'.
+	Schema *string
+
+	// RecordName is the name of the record in the generated schema.
+	// When not set, it defaults to "Row".
+	RecordName string
+
+	// Compression sets the compression codec. The default is AvroNull.
+	Compression AvroCompression
+}
+
+// avroField describes how a Series is encoded into a field of an Avro record.
+type avroField struct {
+	name      string
+	nullable  bool
+	typ       string // underlying Avro type
+	logical   string // Avro logical type
+	unionName string // name of the non-null type within a union
+	symbols   map[string]struct{}
+}
+
+var avroInvalidChars = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+// ExportToAvro exports a Dataframe to an Avro Object Container File.
+//
+// See: https://avro.apache.org/docs/current/spec.html#Object+Container+Files
+func ExportToAvro(ctx context.Context, w io.Writer, df *dataframe.DataFrame, options ...AvroExportOptions) error {
+
+	df.Lock()
+	defer df.Unlock()
+
+	var (
+		r           dataframe.Range
+		schema      string
+		recordName  = "Row"
+		compression = AvroNull
+	)
+
+	if len(options) > 0 {
+		r = options[0].Range
+		if options[0].RecordName != "" {
+			recordName = options[0].RecordName
+		}
+		if options[0].Compression != "" {
+			compression = options[0].Compression
+		}
+		if options[0].Schema != nil {
+			schema = *options[0].Schema
+		}
+	}
+
+	var (
+		fields []avroField
+		err    error
+	)
+
+	if schema == "" {
+		schema, fields = avroGenerateSchema(df, recordName)
+	} else {
+		fields, err = avroParseSchema(df, schema)
+		if err != nil {
+			return err
+		}
+	}
+
+	ocfw, err := goavro.NewOCFWriter(goavro.OCFConfig{
+		W:               w,
+		Schema:          schema,
+		CompressionName: string(compression),
+	})
+	if err != nil {
+		return err
+	}
+
+	nRows := df.NRows(dataframe.DontLock)
+
+	if nRows > 0 {
+
+		s, e, err := r.Limits(nRows)
+		if err != nil {
+			return err
+		}
+
+		const blockSize = 1000
+
+		block := make([]interface{}, 0, blockSize)
+
+		for row := s; row <= e; row++ {
+
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			record := map[string]interface{}{}
+			for i, aSeries := range df.Series {
+				if fields[i].name == "" {
+					continue // Series is not in schema
+				}
+
+				val, err := fields[i].native(aSeries.Value(row, dataframe.DontLock), aSeries.ValueString(row, dataframe.DontLock))
+				if err != nil {
+					return &dataframe.RowError{Row: row, Err: fmt.Errorf("field %s: %v", fields[i].name, err)}
+				}
+				record[fields[i].name] = val
+			}
+
+			block = append(block, record)
+			if len(block) == blockSize || row == e {
+				if err := ocfw.Append(block); err != nil {
+					return err
+				}
+				block = block[:0]
+			}
+		}
+	}
+
+	return nil
+}
+
+func avroGenerateSchema(df *dataframe.DataFrame, recordName string) (string, []avroField) {
+
+	fields := []avroField{}
+	schemaFields := []interface{}{}
+
+	for _, aSeries := range df.Series {
+
+		f := avroField{
+			name:     avroInvalidChars.ReplaceAllString(aSeries.Name(dataframe.DontLock), "_"),
+			nullable: true,
+		}
+
+		var typ interface{}
+
+		switch aSeries.(type) {
+		case *dataframe.SeriesFloat64:
+			f.typ, f.unionName = "double", "double"
+			typ = "double"
+		case *dataframe.SeriesInt64:
+			f.typ, f.unionName = "long", "long"
+			typ = "long"
+		case *dataframe.SeriesTime:
+			f.typ, f.logical, f.unionName = "long", "timestamp-micros", "long.timestamp-micros"
+			typ = map[string]interface{}{"type": "long", "logicalType": "timestamp-micros"}
+		default:
+			f.typ, f.unionName = "string", "string"
+			typ = "string"
+		}
+
+		fields = append(fields, f)
+		schemaFields = append(schemaFields, map[string]interface{}{
+			"name":    f.name,
+			"type":    []interface{}{"null", typ},
+			"default": nil,
+		})
+	}
+
+	schema, _ := json.Marshal(map[string]interface{}{
+		"type":   "record",
+		"name":   recordName,
+		"fields": schemaFields,
+	})
+
+	return string(schema), fields
+}
+
+func avroParseSchema(df *dataframe.DataFrame, schema string) ([]avroField, error) {
+
+	var s map[string]interface{}
+	if err := json.Unmarshal([]byte(schema), &s); err != nil {
+		return nil, err
+	}
+
+	if s["type"] != "record" {
+		return nil, errors.New("avro schema must be a record")
+	}
+
+	schemaFields, _ := s["fields"].([]interface{})
+
+	fields := make([]avroField, len(df.Series))
+	for _, sf := range schemaFields {
+		sfm, ok := sf.(map[string]interface{})
+		if !ok {
+			return nil, errors.New("invalid avro schema field")
+		}
+		name, _ := sfm["name"].(string)
+
+		col, err := df.NameToColumn(name, dataframe.DontLock)
+		if err != nil {
+			if _, hasDefault := sfm["default"]; hasDefault {
+				continue
+			}
+			return nil, fmt.Errorf("no series for avro field: %s", name)
+		}
+
+		f, err := avroParseField(name, sfm["type"])
+		if err != nil {
+			return nil, err
+		}
+		fields[col] = f
+	}
+
+	return fields, nil
+}
+
+func avroParseField(name string, typ interface{}) (avroField, error) {
+
+	f := avroField{name: name}
+
+	switch t := typ.(type) {
+	case string:
+		f.typ, f.unionName = t, t
+	case []interface{}:
+		// Only unions of null and a single other type are supported
+		var other interface{}
+		for _, m := range t {
+			if m == "null" {
+				f.nullable = true
+			} else if other != nil {
+				return f, fmt.Errorf("unsupported avro union for field: %s", name)
+			} else {
+				other = m
+			}
+		}
+		if other == nil {
+			return f, fmt.Errorf("unsupported avro union for field: %s", name)
+		}
+		nf, err := avroParseField(name, other)
+		if err != nil {
+			return f, err
+		}
+		nf.nullable = f.nullable
+		return nf, nil
+	case map[string]interface{}:
+		f.typ, _ = t["type"].(string)
+		f.logical, _ = t["logicalType"].(string)
+
+		if n, ok := t["name"].(string); ok {
+			if ns, ok := t["namespace"].(string); ok && ns != "" {
+				n = ns + "." + n
+			}
+			f.unionName = n
+		} else if f.logical != "" {
+			f.unionName = f.typ + "." + f.logical
+		} else {
+			f.unionName = f.typ
+		}
+
+		if f.typ == "enum" {
+			f.symbols = map[string]struct{}{}
+			symbols, _ := t["symbols"].([]interface{})
+			for _, s := range symbols {
+				f.symbols[s.(string)] = struct{}{}
+			}
+		}
+	default:
+		return f, fmt.Errorf("unsupported avro type for field: %s", name)
+	}
+
+	switch f.typ {
+	case "null", "boolean", "int", "long", "float", "double", "bytes", "string", "enum", "fixed":
+	default:
+		return f, fmt.Errorf("unsupported avro type (%s) for field: %s", f.typ, name)
+	}
+
+	return f, nil
+}
+
+// native converts a value of a Series to its native goavro representation.
+func (f avroField) native(val interface{}, valStr string) (interface{}, error) {
+
+	if val == nil {
+		if !f.nullable {
+			return nil, errors.New("nil value for non-nullable field")
+		}
+		return nil, nil
+	}
+
+	var (
+		out interface{}
+		err error
+	)
+
+	switch f.logical {
+	case "timestamp-millis", "timestamp-micros", "date", "time-millis", "time-micros":
+		switch v := val.(type) {
+		case time.Time:
+			out = v
+			if f.logical == "time-millis" || f.logical == "time-micros" {
+				// goavro expects a duration since midnight
+				y, m, d := v.Date()
+				out = v.Sub(time.Date(y, m, d, 0, 0, 0, 0, v.Location()))
+			}
+		case time.Duration:
+			if f.logical != "time-millis" && f.logical != "time-micros" {
+				return nil, fmt.Errorf("can't convert %T to %s", val, f.logical)
+			}
+			out = v
+		default:
+			return nil, fmt.Errorf("can't convert %T to %s", val, f.logical)
+		}
+		if d, ok := out.(time.Duration); ok && f.logical == "time-micros" {
+			// goavro truncates a time.Duration to 32 bits
+			out = int64(d / time.Microsecond)
+		}
+	case "decimal":
+		switch v := val.(type) {
+		case float64:
+			out = new(big.Rat).SetFloat64(v)
+		case int64:
+			out = new(big.Rat).SetInt64(v)
+		default:
+			rat, ok := new(big.Rat).SetString(valStr)
+			if !ok {
+				return nil, fmt.Errorf("can't convert %T to decimal", val)
+			}
+			out = rat
+		}
+	default:
+		out, err = f.nativePrimitive(val, valStr)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if f.nullable {
+		return goavro.Union(f.unionName, out), nil
+	}
+	return out, nil
+}
+
+func (f avroField) nativePrimitive(val interface{}, valStr string) (interface{}, error) {
+
+	switch f.typ {
+	case "boolean":
+		switch v := val.(type) {
+		case int64:
+			return v != 0, nil
+		case float64:
+			return v != 0, nil
+		case bool:
+			return v, nil
+		default:
+			return strconv.ParseBool(valStr)
+		}
+	case "int", "long":
+		switch v := val.(type) {
+		case int64:
+			return v, nil
+		case float64:
+			return int64(v), nil
+		case time.Time:
+			return v.Unix(), nil
+		default:
+			return strconv.ParseInt(valStr, 10, 64)
+		}
+	case "float", "double":
+		switch v := val.(type) {
+		case int64:
+			return float64(v), nil
+		case float64:
+			return v, nil
+		default:
+			return strconv.ParseFloat(valStr, 64)
+		}
+	case "bytes", "fixed":
+		return []byte(valStr), nil
+	case "enum":
+		if _, exists := f.symbols[valStr]; !exists {
+			return nil, fmt.Errorf("invalid enum symbol: %s", valStr)
+		}
+		return valStr, nil
+	default:
+		if s, ok := val.(string); ok {
+			return s, nil
+		}
+		return valStr, nil
+	}
+}
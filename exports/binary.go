@@ -0,0 +1,67 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package exports
+
+import (
+	"context"
+	"io"
+
+	dataframe "github.com/rocketlaunchr/dataframe-go"
+)
+
+// BinaryExportOptions contains options for ExportToBinary function.
+type BinaryExportOptions struct {
+
+	// Range is used to export a subset of rows from the Dataframe.
+	Range dataframe.Range
+}
+
+// ExportToBinary exports a Dataframe in the native binary format.
+// The format preserves the type, name and values (including nils) of each Series.
+// It is suitable for caching intermediate results, but not for long-term storage.
+//
+// Custom Series must be registered with dataframe.RegisterSeries.
+func ExportToBinary(ctx context.Context, w io.Writer, df *dataframe.DataFrame, options ...BinaryExportOptions) error {
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var r dataframe.Range
+
+	if len(options) > 0 {
+		r = options[0].Range
+	}
+
+	// MarshalBinary locks the DataFrame itself, so it must be called after unlocking.
+	df, err := binarySubset(df, r)
+	if err != nil {
+		return err
+	}
+
+	data, err := df.MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(data)
+	return err
+}
+
+// binarySubset returns a copy of the rows of df within r.
+// If df has no rows, df is returned.
+func binarySubset(df *dataframe.DataFrame, r dataframe.Range) (*dataframe.DataFrame, error) {
+
+	df.Lock()
+	defer df.Unlock()
+
+	nRows := df.NRows(dataframe.DontLock)
+	if nRows == 0 {
+		return df, nil
+	}
+
+	if _, _, err := r.Limits(nRows); err != nil {
+		return nil, err
+	}
+	return df.Copy(r), nil
+}
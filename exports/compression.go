@@ -0,0 +1,49 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package exports
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/ulikunitz/xz"
+)
+
+// Compression sets the compression algorithm used by an exporter.
+type Compression string
+
+const (
+	// NoCompression means the data is not compressed.
+	NoCompression Compression = ""
+	// Gzip compresses the data using the gzip format.
+	Gzip Compression = "gzip"
+	// Zstd compresses the data using the zstd format.
+	Zstd Compression = "zstd"
+	// XZ compresses the data using the xz format.
+	XZ Compression = "xz"
+)
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
+
+// compressWriter wraps w so that the data written is compressed.
+// Close must be called to flush the compressed data. It does not close w.
+func compressWriter(w io.Writer, c Compression) (io.WriteCloser, error) {
+	switch c {
+	case NoCompression:
+		return nopWriteCloser{w}, nil
+	case Gzip:
+		return gzip.NewWriter(w), nil
+	case Zstd:
+		return zstd.NewWriter(w)
+	case XZ:
+		return xz.NewWriter(w)
+	default:
+		return nil, fmt.Errorf("unsupported compression: %s", c)
+	}
+}
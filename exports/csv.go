@@ -27,10 +27,13 @@ type CSVExportOptions struct {
 	// UseCRLF determines the line terminator.
 	// When true, it is set to \r\n.
 	UseCRLF bool
+
+	// Compression sets the compression algorithm. The default is NoCompression.
+	Compression Compression
 }
 
 // ExportToCSV exports a Dataframe to a CSV file.
-func ExportToCSV(ctx context.Context, w io.Writer, df *dataframe.DataFrame, options ...CSVExportOptions) error {
+func ExportToCSV(ctx context.Context, w io.Writer, df *dataframe.DataFrame, options ...CSVExportOptions) (rErr error) {
 
 	df.Lock()
 	defer df.Unlock()
@@ -41,7 +44,22 @@ func ExportToCSV(ctx context.Context, w io.Writer, df *dataframe.DataFrame, opti
 
 	nullString := "NaN" // Default will be "NaN"
 
-	cw := csv.NewWriter(w)
+	var compression Compression
+	if len(options) > 0 {
+		compression = options[0].Compression
+	}
+
+	zw, err := compressWriter(w, compression)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := zw.Close(); err != nil && rErr == nil {
+			rErr = err
+		}
+	}()
+
+	cw := csv.NewWriter(zw)
 
 	if len(options) > 0 {
 		cw.Comma = options[0].Separator
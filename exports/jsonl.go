@@ -24,13 +24,16 @@ type JSONExportOptions struct {
 	// SetEscapeHTML specifies whether problematic HTML characters should be escaped inside JSON quoted strings.
 	// See: https://golang.org/pkg/encoding/json/#Encoder.SetEscapeHTML
 	SetEscapeHTML bool
+
+	// Compression sets the compression algorithm. The default is NoCompression.
+	Compression Compression
 }
 
 // ExportToJSON exports a Dataframe in the jsonl format.
 // Each line represents a row from the Dataframe.
 //
 // See: http://jsonlines.org/ for more information.
-func ExportToJSON(ctx context.Context, w io.Writer, df *dataframe.DataFrame, options ...JSONExportOptions) error {
+func ExportToJSON(ctx context.Context, w io.Writer, df *dataframe.DataFrame, options ...JSONExportOptions) (rErr error) {
 
 	df.Lock()
 	defer df.Unlock()
@@ -38,7 +41,22 @@ func ExportToJSON(ctx context.Context, w io.Writer, df *dataframe.DataFrame, opt
 	var r dataframe.Range
 	var null *string // default is null
 
-	enc := json.NewEncoder(w)
+	var compression Compression
+	if len(options) > 0 {
+		compression = options[0].Compression
+	}
+
+	zw, err := compressWriter(w, compression)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := zw.Close(); err != nil && rErr == nil {
+			rErr = err
+		}
+	}()
+
+	enc := json.NewEncoder(zw)
 
 	if len(options) > 0 {
 
@@ -22,6 +22,51 @@ const (
 	PostgreSQL Database = 0
 	// MySQL database
 	MySQL Database = 1
+	// SQLite database
+	SQLite Database = 2
+	// MSSQL (Microsoft SQL Server) database
+	MSSQL Database = 3
+)
+
+// maxParams is the maximum number of placeholders permitted in a single statement.
+var maxParams = map[Database]int{
+	PostgreSQL: 65535,
+	MySQL:      65535,
+	SQLite:     999, // SQLITE_MAX_VARIABLE_NUMBER prior to v3.32.0
+	MSSQL:      2100,
+}
+
+// maxRows is the maximum number of rows permitted in a single INSERT statement (if limited).
+var maxRows = map[Database]int{
+	MSSQL: 1000,
+}
+
+// timeLayouts is the format used to export time values.
+var timeLayouts = map[Database]string{
+	PostgreSQL: "2006-01-02 15:04:05",
+	MySQL:      "2006-01-02 15:04:05",
+	SQLite:     "2006-01-02 15:04:05",
+	MSSQL:      "2006-01-02T15:04:05.999", // Unambiguous for DATETIME and DATETIME2
+}
+
+// OnConflict sets the behavior when an inserted row conflicts with an existing row
+// (i.e. a unique or primary key constraint is violated).
+type OnConflict int
+
+const (
+	// OnConflictError returns an error from the database.
+	OnConflictError OnConflict = 0
+	// OnConflictIgnore skips the conflicting row.
+	OnConflictIgnore OnConflict = 1
+	// OnConflictUpdate updates the existing row with the values of the conflicting row.
+	//
+	// PostgreSQL & SQLite: ON CONFLICT DO UPDATE, MySQL: ON DUPLICATE KEY UPDATE, MSSQL: MERGE.
+	OnConflictUpdate OnConflict = 2
+	// OnConflictReplace replaces the existing row with the conflicting row.
+	//
+	// MySQL: REPLACE INTO, SQLite: INSERT OR REPLACE INTO.
+	// PostgreSQL and MSSQL do not support replacing rows, so it behaves like OnConflictUpdate.
+	OnConflictReplace OnConflict = 3
 )
 
 type execContexter interface {
@@ -45,6 +90,9 @@ type SQLExportOptions struct {
 	// It is recommended a transaction is used so if 1 batch-insert fails, then all
 	// successfully inserted data can be rolled back.
 	// If set, it must not be 0.
+	//
+	// NOTE: Irrespective of BatchSize, batches are limited by the maximum number of placeholders
+	// permitted by the Database (eg. 999 for SQLite and 2100 for MSSQL). MSSQL is also limited to 1000 rows.
 	BatchSize *uint
 
 	// SeriesToColumn is used to map the series name to the table's column name.
@@ -55,6 +103,29 @@ type SQLExportOptions struct {
 
 	// Database is used to set the Database.
 	Database Database
+
+	// CreateTable will generate and execute a CREATE TABLE statement (if the table does not exist) based on the types of the Series.
+	//
+	//  Series          PostgreSQL         MySQL      SQLite     MSSQL
+	//  SeriesFloat64   DOUBLE PRECISION   DOUBLE     REAL       FLOAT
+	//  SeriesInt64     BIGINT             BIGINT     INTEGER    BIGINT
+	//  SeriesTime      TIMESTAMP          DATETIME   TIMESTAMP  DATETIME2
+	//  other           TEXT               TEXT       TEXT       NVARCHAR(MAX)
+	//
+	// String columns in UniqueColumns are mapped to VARCHAR(255) for MySQL and NVARCHAR(255) for MSSQL.
+	// The PrimaryKey column is auto-incrementing if PrimaryKey.Value is nil, otherwise it is a string of up to 255 characters.
+	CreateTable bool
+
+	// OnConflict sets the behavior when an inserted row conflicts with an existing row.
+	// The default is OnConflictError.
+	OnConflict OnConflict
+
+	// UniqueColumns are the column names that uniquely identify a row for the purposes of OnConflict.
+	// When CreateTable is set, a UNIQUE constraint is also created.
+	// If not set, the PrimaryKey column is used (unless it is auto-incrementing).
+	//
+	// NOTE: PostgreSQL requires the conflict target for OnConflictUpdate and OnConflictReplace.
+	UniqueColumns []string
 }
 
 // PrimaryKey is used to generate custom values for the primary key
@@ -65,13 +136,14 @@ type PrimaryKey struct {
 
 	// Value is a function that generates a primary key value given the row number
 	// and number of rows in the Dataframe.
-	// For auto-incrementing primary keys, nil can be returned.
+	// For auto-incrementing primary keys, Value can be nil. The primary key column is then omitted
+	// from the inserted rows (and is not used as the default for UniqueColumns).
 	Value func(row int, n int) *string
 }
 
 // ExportToSQL exports a Dataframe to a SQL Database.
 // It is assumed to be a PostgreSQL database (for placeholder purposes), unless
-// otherwise set to MySQL using the Options.
+// otherwise set to MySQL, SQLite or MSSQL using the Options.
 //
 // Example (gist):
 //
@@ -127,11 +199,14 @@ func ExportToSQL(ctx context.Context, db execContexter, df *dataframe.DataFrame,
 	defer df.Unlock()
 
 	var (
-		null      *string
-		r         dataframe.Range
-		pk        *PrimaryKey
-		batchSize *uint
-		database  Database
+		null          *string
+		r             dataframe.Range
+		pk            *PrimaryKey
+		batchSize     *uint
+		database      Database
+		createTable   bool
+		onConflict    OnConflict
+		uniqueColumns []string
 	)
 
 	if tableName == "" {
@@ -155,29 +230,33 @@ func ExportToSQL(ctx context.Context, db execContexter, df *dataframe.DataFrame,
 			seriesToColumn = options[0].SeriesToColumn
 		}
 		database = options[0].Database
-		if database != PostgreSQL && database != MySQL {
+		if _, exists := maxParams[database]; !exists {
 			return errors.New("invalid database")
 		}
+		createTable = options[0].CreateTable
+		onConflict = options[0].OnConflict
+		uniqueColumns = options[0].UniqueColumns
 	}
 
-	nRows := df.NRows(dataframe.DontLock)
-	if nRows == 0 {
-		return nil
-	}
+	// An auto-generated primary key is not inserted
+	autoPK := pk != nil && pk.Value == nil
 
-	start, end, err := r.Limits(nRows)
-	if err != nil {
-		return err
+	if len(uniqueColumns) == 0 && pk != nil && !autoPK {
+		uniqueColumns = []string{pk.PrimaryKey}
 	}
 
 	// Determine column names
 	columnNames := []string{}
+	columnSeries := []dataframe.Series{} // nil for primary key
 
-	if pk != nil {
+	if pk != nil && !autoPK {
 		columnNames = append(columnNames, pk.PrimaryKey)
+		columnSeries = append(columnSeries, nil)
 	}
 
-	for _, seriesName := range df.Names(dataframe.DontLock) {
+	for _, series := range df.Series {
+
+		seriesName := series.Name(dataframe.DontLock)
 
 		colName, exists := seriesToColumn[seriesName]
 		if exists && colName == nil {
@@ -192,6 +271,45 @@ func ExportToSQL(ctx context.Context, db execContexter, df *dataframe.DataFrame,
 			// Use provided column name
 			columnNames = append(columnNames, *colName)
 		}
+		columnSeries = append(columnSeries, series)
+	}
+
+	if len(columnNames) == 0 {
+		return errors.New("no columns to export")
+	}
+
+	insert, err := sqlConflict(database, onConflict, columnNames, uniqueColumns)
+	if err != nil {
+		return err
+	}
+
+	if createTable {
+		stmt := sqlCreateTable(database, tableName, columnNames, columnSeries, pk, uniqueColumns, len(options) > 0 && options[0].UniqueColumns != nil)
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			return err
+		}
+	}
+
+	nRows := df.NRows(dataframe.DontLock)
+	if nRows == 0 {
+		return nil
+	}
+
+	start, end, err := r.Limits(nRows)
+	if err != nil {
+		return err
+	}
+
+	// Limit batch size based on the database
+	limit := uint(maxParams[database] / len(columnNames))
+	if rows, exists := maxRows[database]; exists && uint(rows) < limit {
+		limit = uint(rows)
+	}
+	if limit == 0 {
+		limit = 1
+	}
+	if batchSize == nil || *batchSize > limit {
+		batchSize = &limit
 	}
 
 	var (
@@ -210,12 +328,8 @@ func ExportToSQL(ctx context.Context, db execContexter, df *dataframe.DataFrame,
 		batchCount = batchCount + 1
 
 		// Insert primary key
-		if pk != nil {
-			if pk.Value != nil {
-				batchData = append(batchData, pk.Value(row, nRows))
-			} else {
-				batchData = append(batchData, nil)
-			}
+		if pk != nil && !autoPK {
+			batchData = append(batchData, pk.Value(row, nRows))
 		}
 
 		for _, series := range df.Series {
@@ -235,7 +349,7 @@ func ExportToSQL(ctx context.Context, db execContexter, df *dataframe.DataFrame,
 			} else {
 				switch v := val.(type) {
 				case time.Time:
-					ival = &[]string{v.Format("2006-01-02 15:04:05")}[0]
+					ival = &[]string{v.Format(timeLayouts[database])}[0]
 				default:
 					ival = &[]string{series.ValueString(row, dataframe.DontLock)}[0]
 				}
@@ -244,9 +358,9 @@ func ExportToSQL(ctx context.Context, db execContexter, df *dataframe.DataFrame,
 			batchData = append(batchData, ival)
 		}
 
-		if batchSize != nil && batchCount == *batchSize {
+		if batchCount == *batchSize {
 			// Now insert data to table
-			err := sqlInsert(ctx, db, database, tableName, columnNames, batchData)
+			err := sqlInsert(ctx, db, database, tableName, columnNames, batchData, insert)
 			if err != nil {
 				return err
 			}
@@ -259,7 +373,7 @@ func ExportToSQL(ctx context.Context, db execContexter, df *dataframe.DataFrame,
 
 	// Insert the remaining data into table
 	if len(batchData) > 0 {
-		err := sqlInsert(ctx, db, database, tableName, columnNames, batchData)
+		err := sqlInsert(ctx, db, database, tableName, columnNames, batchData, insert)
 		if err != nil {
 			return err
 		}
@@ -268,13 +382,18 @@ func ExportToSQL(ctx context.Context, db execContexter, df *dataframe.DataFrame,
 	return nil
 }
 
-func sqlInsert(ctx context.Context, db execContexter, database Database, tableName string, columnNames []string, batchData []interface{}) error {
+func sqlInsert(ctx context.Context, db execContexter, database Database, tableName string, columnNames []string, batchData []interface{}, insert sqlInsertStmt) error {
 
 	tableName = strings.Join(escapeNames(database, []string{tableName}), ",")
 	columns := strings.Join(escapeNames(database, columnNames), ",")
 	placeholders := placeholders(database, columnNames, len(batchData)/len(columnNames))
 
-	stmt := "INSERT INTO " + tableName + " (" + columns + ") VALUES " + placeholders
+	var stmt string
+	if insert.merge {
+		stmt = insert.verb + " " + tableName + " AS t USING (VALUES " + placeholders + ") AS s (" + columns + ")" + insert.suffix
+	} else {
+		stmt = insert.verb + " " + tableName + " (" + columns + ") VALUES " + placeholders + insert.suffix
+	}
 
 	_, err := db.ExecContext(ctx, stmt, batchData...)
 	if err != nil {
@@ -284,20 +403,205 @@ func sqlInsert(ctx context.Context, db execContexter, database Database, tableNa
 	return nil
 }
 
+// sqlInsertStmt describes the form of the statement used to insert rows.
+type sqlInsertStmt struct {
+	verb   string // eg. INSERT INTO
+	suffix string
+
+	// merge signifies a MERGE statement (MSSQL), where the inserted rows form
+	// the source table and suffix follows the source table.
+	merge bool
+}
+
+// sqlConflict returns the form of the insert statement required to implement the OnConflict behavior.
+func sqlConflict(database Database, onConflict OnConflict, columnNames []string, uniqueColumns []string) (sqlInsertStmt, error) {
+
+	unique := map[string]struct{}{}
+	for _, c := range uniqueColumns {
+		unique[c] = struct{}{}
+	}
+
+	// Columns that are updated
+	updates := []string{}
+	for _, c := range columnNames {
+		if _, exists := unique[c]; !exists {
+			updates = append(updates, c)
+		}
+	}
+
+	target := ""
+	if len(uniqueColumns) > 0 {
+		target = " (" + strings.Join(escapeNames(database, uniqueColumns), ",") + ")"
+	}
+
+	if onConflict == OnConflictError {
+		return sqlInsertStmt{verb: "INSERT INTO"}, nil
+	} else if onConflict != OnConflictIgnore && onConflict != OnConflictUpdate && onConflict != OnConflictReplace {
+		return sqlInsertStmt{}, errors.New("invalid OnConflict")
+	}
+
+	switch database {
+	case MySQL:
+		switch onConflict {
+		case OnConflictIgnore:
+			return sqlInsertStmt{verb: "INSERT IGNORE INTO"}, nil
+		case OnConflictReplace:
+			return sqlInsertStmt{verb: "REPLACE INTO"}, nil
+		}
+
+		if len(updates) == 0 {
+			// Nothing to update, but ON DUPLICATE KEY UPDATE requires an assignment
+			c := escapeNames(database, columnNames[:1])[0]
+			return sqlInsertStmt{verb: "INSERT INTO", suffix: " ON DUPLICATE KEY UPDATE " + c + " = " + c}, nil
+		}
+
+		sets := []string{}
+		for _, c := range escapeNames(database, updates) {
+			sets = append(sets, c+" = VALUES("+c+")")
+		}
+		return sqlInsertStmt{verb: "INSERT INTO", suffix: " ON DUPLICATE KEY UPDATE " + strings.Join(sets, ", ")}, nil
+	case MSSQL:
+		if len(uniqueColumns) == 0 {
+			return sqlInsertStmt{}, errors.New("UniqueColumns or PrimaryKey must be provided")
+		}
+
+		on := []string{}
+		for _, c := range escapeNames(database, uniqueColumns) {
+			on = append(on, "t."+c+" = s."+c)
+		}
+
+		sets := []string{}
+		for _, c := range escapeNames(database, updates) {
+			sets = append(sets, "t."+c+" = s."+c)
+		}
+
+		values := []string{}
+		for _, c := range escapeNames(database, columnNames) {
+			values = append(values, "s."+c)
+		}
+
+		suffix := " ON " + strings.Join(on, " AND ")
+		if onConflict != OnConflictIgnore && len(sets) > 0 {
+			suffix = suffix + " WHEN MATCHED THEN UPDATE SET " + strings.Join(sets, ", ")
+		}
+		suffix = suffix + " WHEN NOT MATCHED THEN INSERT (" + strings.Join(escapeNames(database, columnNames), ",") + ") VALUES (" + strings.Join(values, ",") + ");"
+
+		return sqlInsertStmt{verb: "MERGE INTO", suffix: suffix, merge: true}, nil
+	}
+
+	// PostgreSQL and SQLite
+	if database == SQLite {
+		switch onConflict {
+		case OnConflictIgnore:
+			return sqlInsertStmt{verb: "INSERT OR IGNORE INTO"}, nil
+		case OnConflictReplace:
+			return sqlInsertStmt{verb: "INSERT OR REPLACE INTO"}, nil
+		}
+	}
+
+	if onConflict == OnConflictIgnore {
+		return sqlInsertStmt{verb: "INSERT INTO", suffix: " ON CONFLICT" + target + " DO NOTHING"}, nil
+	}
+
+	if target == "" {
+		return sqlInsertStmt{}, errors.New("UniqueColumns or PrimaryKey must be provided")
+	}
+
+	if len(updates) == 0 {
+		return sqlInsertStmt{verb: "INSERT INTO", suffix: " ON CONFLICT" + target + " DO NOTHING"}, nil
+	}
+
+	sets := []string{}
+	for _, c := range escapeNames(database, updates) {
+		sets = append(sets, c+" = EXCLUDED."+c)
+	}
+	return sqlInsertStmt{verb: "INSERT INTO", suffix: " ON CONFLICT" + target + " DO UPDATE SET " + strings.Join(sets, ", ")}, nil
+}
+
+// sqlColumnTypes contains the column types used by CREATE TABLE for each database.
+var sqlColumnTypes = map[Database]struct {
+	autoIncrement, key, float, int, time, text, uniqueText string
+}{
+	PostgreSQL: {"BIGSERIAL PRIMARY KEY", "VARCHAR(255) PRIMARY KEY", "DOUBLE PRECISION", "BIGINT", "TIMESTAMP", "TEXT", "TEXT"},
+	MySQL:      {"BIGINT AUTO_INCREMENT PRIMARY KEY", "VARCHAR(255) PRIMARY KEY", "DOUBLE", "BIGINT", "DATETIME", "TEXT", "VARCHAR(255)"},
+	SQLite:     {"INTEGER PRIMARY KEY AUTOINCREMENT", "TEXT PRIMARY KEY", "REAL", "INTEGER", "TIMESTAMP", "TEXT", "TEXT"},
+	MSSQL:      {"BIGINT IDENTITY(1,1) PRIMARY KEY", "NVARCHAR(255) PRIMARY KEY", "FLOAT", "BIGINT", "DATETIME2", "NVARCHAR(MAX)", "NVARCHAR(255)"},
+}
+
+// sqlCreateTable generates a CREATE TABLE statement. A nil Series signifies the primary key.
+// If pk generates no values, an auto-incrementing primary key column is added.
+func sqlCreateTable(database Database, tableName string, columnNames []string, columnSeries []dataframe.Series, pk *PrimaryKey, uniqueColumns []string, uniqueConstraint bool) string {
+
+	types := sqlColumnTypes[database]
+
+	unique := map[string]struct{}{}
+	for _, c := range uniqueColumns {
+		unique[c] = struct{}{}
+	}
+
+	defs := []string{}
+	if pk != nil && pk.Value == nil {
+		defs = append(defs, escapeNames(database, []string{pk.PrimaryKey})[0]+" "+types.autoIncrement)
+	}
+
+	for i, name := range columnNames {
+
+		var typ string
+
+		switch columnSeries[i].(type) {
+		case nil:
+			typ = types.key
+		case *dataframe.SeriesFloat64:
+			typ = types.float
+		case *dataframe.SeriesInt64:
+			typ = types.int
+		case *dataframe.SeriesTime:
+			typ = types.time
+		default:
+			typ = types.text
+			if _, exists := unique[name]; exists {
+				// Some databases can't index a TEXT column without a length
+				typ = types.uniqueText
+			}
+		}
+
+		defs = append(defs, escapeNames(database, []string{name})[0]+" "+typ)
+	}
+
+	if uniqueConstraint && len(uniqueColumns) > 0 {
+		defs = append(defs, "UNIQUE ("+strings.Join(escapeNames(database, uniqueColumns), ",")+")")
+	}
+
+	table := escapeNames(database, []string{tableName})[0]
+	columns := " (" + strings.Join(defs, ", ") + ")"
+
+	if database == MSSQL {
+		// MSSQL does not support IF NOT EXISTS
+		return "IF OBJECT_ID(N'" + strings.Replace(tableName, "'", "''", -1) + "', N'U') IS NULL CREATE TABLE " + table + columns
+	}
+
+	return "CREATE TABLE IF NOT EXISTS " + table + columns
+}
+
 func placeholders(dbtype Database, fields []string, rows int) string {
 
-	if dbtype == MySQL {
+	if dbtype == MySQL || dbtype == SQLite {
 		inner := "( " + strings.TrimSuffix(strings.Repeat("?,", len(fields)), ",") + " ),"
 		return strings.TrimSuffix(strings.Repeat(inner, rows), ",")
 	}
 
+	format := "$%d,"
+	if dbtype == MSSQL {
+		format = "@p%d,"
+	}
+
 	var singleValuesStr string
 
 	varCount := 1
 	for i := 1; i <= rows; i++ {
 		singleValuesStr = singleValuesStr + "("
 		for j := 1; j <= len(fields); j++ {
-			singleValuesStr = singleValuesStr + fmt.Sprintf("$%d,", varCount)
+			singleValuesStr = singleValuesStr + fmt.Sprintf(format, varCount)
 			varCount++
 		}
 		singleValuesStr = strings.TrimSuffix(singleValuesStr, ",") + "),"
@@ -312,11 +616,15 @@ func escapeNames(database Database, names []string) []string {
 	switch database {
 	case MySQL:
 		for _, v := range names {
-			out = append(out, fmt.Sprintf("`%s`", v))
+			out = append(out, fmt.Sprintf("`%s`", strings.Replace(v, "`", "``", -1)))
+		}
+	case PostgreSQL, SQLite:
+		for _, v := range names {
+			out = append(out, fmt.Sprintf("\"%s\"", strings.Replace(v, "\"", "\"\"", -1)))
 		}
-	case PostgreSQL:
+	case MSSQL:
 		for _, v := range names {
-			out = append(out, fmt.Sprintf("\"%s\"", v))
+			out = append(out, fmt.Sprintf("[%s]", strings.Replace(v, "]", "]]", -1)))
 		}
 	default:
 		out = names
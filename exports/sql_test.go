@@ -0,0 +1,280 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package exports
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+	"testing"
+
+	dataframe "github.com/rocketlaunchr/dataframe-go"
+)
+
+var ctx = context.Background()
+
+// testDB records the statements that are executed.
+type testDB struct {
+	stmts []string
+	args  [][]interface{}
+}
+
+func (db *testDB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	db.stmts = append(db.stmts, query)
+	db.args = append(db.args, args)
+	return nil, nil
+}
+
+func TestSQLConflict(t *testing.T) {
+
+	cols := []string{"id", "name", "age"}
+	unique := []string{"id"}
+
+	tests := []struct {
+		database   Database
+		onConflict OnConflict
+		verb       string
+		suffix     string
+	}{
+		{PostgreSQL, OnConflictError, "INSERT INTO", ""},
+		{PostgreSQL, OnConflictIgnore, "INSERT INTO", ` ON CONFLICT ("id") DO NOTHING`},
+		{PostgreSQL, OnConflictUpdate, "INSERT INTO", ` ON CONFLICT ("id") DO UPDATE SET "name" = EXCLUDED."name", "age" = EXCLUDED."age"`},
+		{PostgreSQL, OnConflictReplace, "INSERT INTO", ` ON CONFLICT ("id") DO UPDATE SET "name" = EXCLUDED."name", "age" = EXCLUDED."age"`},
+		{MySQL, OnConflictError, "INSERT INTO", ""},
+		{MySQL, OnConflictIgnore, "INSERT IGNORE INTO", ""},
+		{MySQL, OnConflictUpdate, "INSERT INTO", " ON DUPLICATE KEY UPDATE `name` = VALUES(`name`), `age` = VALUES(`age`)"},
+		{MySQL, OnConflictReplace, "REPLACE INTO", ""},
+		{SQLite, OnConflictError, "INSERT INTO", ""},
+		{SQLite, OnConflictIgnore, "INSERT OR IGNORE INTO", ""},
+		{SQLite, OnConflictUpdate, "INSERT INTO", ` ON CONFLICT ("id") DO UPDATE SET "name" = EXCLUDED."name", "age" = EXCLUDED."age"`},
+		{SQLite, OnConflictReplace, "INSERT OR REPLACE INTO", ""},
+		{MSSQL, OnConflictError, "INSERT INTO", ""},
+		{MSSQL, OnConflictIgnore, "MERGE INTO", " ON t.[id] = s.[id] WHEN NOT MATCHED THEN INSERT ([id],[name],[age]) VALUES (s.[id],s.[name],s.[age]);"},
+		{MSSQL, OnConflictUpdate, "MERGE INTO", " ON t.[id] = s.[id] WHEN MATCHED THEN UPDATE SET t.[name] = s.[name], t.[age] = s.[age] WHEN NOT MATCHED THEN INSERT ([id],[name],[age]) VALUES (s.[id],s.[name],s.[age]);"},
+	}
+
+	for i, tc := range tests {
+		insert, err := sqlConflict(tc.database, tc.onConflict, cols, unique)
+		if err != nil {
+			t.Errorf("%d: error encountered: %v", i, err)
+			continue
+		}
+
+		expected := sqlInsertStmt{verb: tc.verb, suffix: tc.suffix, merge: tc.verb == "MERGE INTO"}
+		if insert != expected {
+			t.Errorf("%d: wrong statement: expected: %+v actual: %+v", i, expected, insert)
+		}
+	}
+
+	// The conflict target is required
+	for _, database := range []Database{PostgreSQL, SQLite, MSSQL} {
+		if _, err := sqlConflict(database, OnConflictUpdate, cols, nil); err == nil {
+			t.Errorf("%d: expected error for missing UniqueColumns", database)
+		}
+	}
+}
+
+func TestSQLCreateTable(t *testing.T) {
+
+	cols := []string{"id", "name", "age", "score", "at"}
+	seriess := []dataframe.Series{
+		nil,
+		dataframe.NewSeriesString("name", nil),
+		dataframe.NewSeriesInt64("age", nil),
+		dataframe.NewSeriesFloat64("score", nil),
+		dataframe.NewSeriesTime("at", nil),
+	}
+
+	keyPK := &PrimaryKey{PrimaryKey: "id", Value: func(row int, n int) *string { return nil }}
+	autoPK := &PrimaryKey{PrimaryKey: "id"}
+
+	tests := []struct {
+		database Database
+		key      string // Value provided and name is unique
+		auto     string // auto-incrementing primary key
+	}{
+		{
+			PostgreSQL,
+			`CREATE TABLE IF NOT EXISTS "people" ("id" VARCHAR(255) PRIMARY KEY, "name" TEXT, "age" BIGINT, "score" DOUBLE PRECISION, "at" TIMESTAMP, UNIQUE ("name"))`,
+			`CREATE TABLE IF NOT EXISTS "people" ("id" BIGSERIAL PRIMARY KEY, "name" TEXT, "age" BIGINT, "score" DOUBLE PRECISION, "at" TIMESTAMP)`,
+		},
+		{
+			MySQL,
+			"CREATE TABLE IF NOT EXISTS `people` (`id` VARCHAR(255) PRIMARY KEY, `name` VARCHAR(255), `age` BIGINT, `score` DOUBLE, `at` DATETIME, UNIQUE (`name`))",
+			"CREATE TABLE IF NOT EXISTS `people` (`id` BIGINT AUTO_INCREMENT PRIMARY KEY, `name` TEXT, `age` BIGINT, `score` DOUBLE, `at` DATETIME)",
+		},
+		{
+			SQLite,
+			`CREATE TABLE IF NOT EXISTS "people" ("id" TEXT PRIMARY KEY, "name" TEXT, "age" INTEGER, "score" REAL, "at" TIMESTAMP, UNIQUE ("name"))`,
+			`CREATE TABLE IF NOT EXISTS "people" ("id" INTEGER PRIMARY KEY AUTOINCREMENT, "name" TEXT, "age" INTEGER, "score" REAL, "at" TIMESTAMP)`,
+		},
+		{
+			MSSQL,
+			`IF OBJECT_ID(N'people', N'U') IS NULL CREATE TABLE [people] ([id] NVARCHAR(255) PRIMARY KEY, [name] NVARCHAR(255), [age] BIGINT, [score] FLOAT, [at] DATETIME2, UNIQUE ([name]))`,
+			`IF OBJECT_ID(N'people', N'U') IS NULL CREATE TABLE [people] ([id] BIGINT IDENTITY(1,1) PRIMARY KEY, [name] NVARCHAR(MAX), [age] BIGINT, [score] FLOAT, [at] DATETIME2)`,
+		},
+	}
+
+	for _, tc := range tests {
+		stmt := sqlCreateTable(tc.database, "people", cols, seriess, keyPK, []string{"name"}, true)
+		if stmt != tc.key {
+			t.Errorf("%d: wrong statement:\nexpected: %s\nactual:   %s", tc.database, tc.key, stmt)
+		}
+
+		// The auto-incrementing primary key is not part of the inserted columns
+		stmt = sqlCreateTable(tc.database, "people", cols[1:], seriess[1:], autoPK, nil, false)
+		if stmt != tc.auto {
+			t.Errorf("%d: wrong statement:\nexpected: %s\nactual:   %s", tc.database, tc.auto, stmt)
+		}
+	}
+}
+
+func TestExportToSQLAutoIncrement(t *testing.T) {
+
+	df := dataframe.NewDataFrame(
+		dataframe.NewSeriesString("name", nil, "a", "b"),
+		dataframe.NewSeriesInt64("age", nil, 1, nil),
+	)
+
+	db := &testDB{}
+
+	opts := SQLExportOptions{
+		PrimaryKey:  &PrimaryKey{PrimaryKey: "id"},
+		CreateTable: true,
+	}
+
+	if err := ExportToSQL(ctx, db, df, "people", opts); err != nil {
+		t.Fatalf("error encountered: %v", err)
+	}
+
+	expected := []string{
+		`CREATE TABLE IF NOT EXISTS "people" ("id" BIGSERIAL PRIMARY KEY, "name" TEXT, "age" BIGINT)`,
+		`INSERT INTO "people" ("name","age") VALUES ($1,$2),($3,$4)`,
+	}
+
+	if !reflect.DeepEqual(db.stmts, expected) {
+		t.Errorf("wrong statements:\nexpected: %q\nactual:   %q", expected, db.stmts)
+	}
+
+	if len(db.args) != 2 || len(db.args[1]) != 4 {
+		t.Errorf("wrong args: %v", db.args)
+	}
+}
+
+func TestExportToSQLDialects(t *testing.T) {
+
+	df := dataframe.NewDataFrame(
+		dataframe.NewSeriesString("name", nil, "a", "b", "c"),
+		dataframe.NewSeriesInt64("age", nil, 1, nil, 3),
+	)
+
+	pk := &PrimaryKey{
+		PrimaryKey: "id",
+		Value: func(row int, n int) *string {
+			return &[]string{string('x' + rune(row))}[0]
+		},
+	}
+
+	tests := []struct {
+		opts     SQLExportOptions
+		expected []string
+	}{
+		{
+			SQLExportOptions{Database: SQLite, BatchSize: &[]uint{2}[0]},
+			[]string{
+				`INSERT INTO "people" ("name","age") VALUES ( ?,? ),( ?,? )`,
+				`INSERT INTO "people" ("name","age") VALUES ( ?,? )`,
+			},
+		},
+		{
+			SQLExportOptions{Database: SQLite, PrimaryKey: pk, OnConflict: OnConflictReplace},
+			[]string{
+				`INSERT OR REPLACE INTO "people" ("id","name","age") VALUES ( ?,?,? ),( ?,?,? ),( ?,?,? )`,
+			},
+		},
+		{
+			SQLExportOptions{Database: MSSQL, PrimaryKey: &PrimaryKey{PrimaryKey: "id"}, CreateTable: true},
+			[]string{
+				`IF OBJECT_ID(N'people', N'U') IS NULL CREATE TABLE [people] ([id] BIGINT IDENTITY(1,1) PRIMARY KEY, [name] NVARCHAR(MAX), [age] BIGINT)`,
+				`INSERT INTO [people] ([name],[age]) VALUES (@p1,@p2),(@p3,@p4),(@p5,@p6)`,
+			},
+		},
+		{
+			SQLExportOptions{Database: MSSQL, PrimaryKey: pk, OnConflict: OnConflictUpdate},
+			[]string{
+				`MERGE INTO [people] AS t USING (VALUES (@p1,@p2,@p3),(@p4,@p5,@p6),(@p7,@p8,@p9)) AS s ([id],[name],[age]) ON t.[id] = s.[id] WHEN MATCHED THEN UPDATE SET t.[name] = s.[name], t.[age] = s.[age] WHEN NOT MATCHED THEN INSERT ([id],[name],[age]) VALUES (s.[id],s.[name],s.[age]);`,
+			},
+		},
+	}
+
+	for i, tc := range tests {
+		db := &testDB{}
+
+		if err := ExportToSQL(ctx, db, df, "people", tc.opts); err != nil {
+			t.Errorf("%d: error encountered: %v", i, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(db.stmts, tc.expected) {
+			t.Errorf("%d: wrong statements:\nexpected: %q\nactual:   %q", i, tc.expected, db.stmts)
+		}
+	}
+}
+
+func TestExportToSQLLimits(t *testing.T) {
+
+	vals := make([]interface{}, 1500)
+	for i := range vals {
+		vals[i] = int64(i)
+	}
+
+	df := dataframe.NewDataFrame(dataframe.NewSeriesInt64("a", nil, vals...))
+
+	tests := []struct {
+		database Database
+		rows     []int // number of rows per statement
+	}{
+		{SQLite, []int{999, 501}}, // 999 placeholders
+		{MSSQL, []int{1000, 500}}, // 1000 rows
+		{PostgreSQL, []int{1500}},
+	}
+
+	for _, tc := range tests {
+		db := &testDB{}
+
+		if err := ExportToSQL(ctx, db, df, "t", SQLExportOptions{Database: tc.database}); err != nil {
+			t.Errorf("%d: error encountered: %v", tc.database, err)
+			continue
+		}
+
+		rows := []int{}
+		for _, args := range db.args {
+			rows = append(rows, len(args))
+		}
+
+		if !reflect.DeepEqual(rows, tc.rows) {
+			t.Errorf("%d: wrong batches: expected: %v actual: %v", tc.database, tc.rows, rows)
+		}
+	}
+}
+
+func TestEscapeNames(t *testing.T) {
+
+	names := []string{"a", `b"c`, "d`e", "f]g"}
+
+	tests := []struct {
+		database Database
+		expected []string
+	}{
+		{PostgreSQL, []string{`"a"`, `"b""c"`, "\"d`e\"", `"f]g"`}},
+		{SQLite, []string{`"a"`, `"b""c"`, "\"d`e\"", `"f]g"`}},
+		{MySQL, []string{"`a`", "`b\"c`", "`d``e`", "`f]g`"}},
+		{MSSQL, []string{"[a]", `[b"c]`, "[d`e]", "[f]]g]"}},
+	}
+
+	for _, tc := range tests {
+		if actual := escapeNames(tc.database, names); !reflect.DeepEqual(actual, tc.expected) {
+			t.Errorf("%d: wrong names: expected: %v actual: %v", tc.database, tc.expected, actual)
+		}
+	}
+}
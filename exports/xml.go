@@ -0,0 +1,210 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package exports
+
+import (
+	"context"
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	dataframe "github.com/rocketlaunchr/dataframe-go"
+)
+
+// XMLExportOptions contains options for ExportToXML function.
+type XMLExportOptions struct {
+
+	// NullString is used to set what nil values should be encoded to.
+	// Common options are NULL, \N, NaN, NA.
+	// If not set, then nil values are omitted.
+	NullString *string
+
+	// Range is used to export a subset of rows from the Dataframe.
+	Range dataframe.Range
+
+	// RootElement is the name of the root element. The default is "dataframe".
+	RootElement string
+
+	// RowElement is the name of the element that represents each row. The default is "row".
+	RowElement string
+
+	// AsAttributes can be set to true if values should be encoded as attributes of the row element.
+	// Otherwise, values are encoded as child elements.
+	AsAttributes bool
+
+	// Indent sets the string used to indent each level. When not set, no indentation is used.
+	Indent string
+
+	// Compression sets the compression algorithm. The default is NoCompression.
+	Compression Compression
+}
+
+// ExportToXML exports a Dataframe to a xml file.
+// Series names are used as element or attribute names. Characters that are not permitted in a xml name
+// are replaced with "_". If names clash, a suffix is appended (eg. "a_b_2"). time.Time values are encoded in RFC3339 format.
+//
+// Example output:
+//
+//  <dataframe>
+//     <row><day>1</day><sales>50.3</sales></row>
+//     <row><day>2</day><sales>23.4</sales></row>
+//  </dataframe>
+//
+func ExportToXML(ctx context.Context, w io.Writer, df *dataframe.DataFrame, options ...XMLExportOptions) (rErr error) {
+
+	df.Lock()
+	defer df.Unlock()
+
+	var r dataframe.Range
+	var null *string // default is to omit nil values
+
+	rootElement := "dataframe"
+	rowElement := "row"
+	asAttributes := false
+
+	var compression Compression
+	var indent string
+
+	if len(options) > 0 {
+		r = options[0].Range
+		null = options[0].NullString
+		asAttributes = options[0].AsAttributes
+		compression = options[0].Compression
+		indent = options[0].Indent
+
+		if options[0].RootElement != "" {
+			rootElement = options[0].RootElement
+		}
+		if options[0].RowElement != "" {
+			rowElement = options[0].RowElement
+		}
+	}
+
+	zw, err := compressWriter(w, compression)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := zw.Close(); err != nil && rErr == nil {
+			rErr = err
+		}
+	}()
+
+	if _, err := io.WriteString(zw, xml.Header); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(zw)
+	enc.Indent("", indent)
+
+	names := []xml.Name{}
+	used := map[string]struct{}{}
+	for _, aSeries := range df.Series {
+		name := xmlName(aSeries.Name())
+		for i := 2; ; i++ {
+			if _, exists := used[name]; !exists {
+				break
+			}
+			name = xmlName(aSeries.Name()) + "_" + strconv.Itoa(i)
+		}
+		used[name] = struct{}{}
+		names = append(names, xml.Name{Local: name})
+	}
+
+	root := xml.StartElement{Name: xml.Name{Local: xmlName(rootElement)}}
+	if err := enc.EncodeToken(root); err != nil {
+		return err
+	}
+
+	nRows := df.NRows(dataframe.DontLock)
+
+	if nRows > 0 {
+
+		s, e, err := r.Limits(nRows)
+		if err != nil {
+			return err
+		}
+
+		for row := s; row <= e; row++ {
+
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			start := xml.StartElement{Name: xml.Name{Local: xmlName(rowElement)}}
+			children := []xml.StartElement{}
+			values := []string{}
+
+			for idx, aSeries := range df.Series {
+
+				var str string
+
+				switch val := aSeries.Value(row).(type) {
+				case nil:
+					if null == nil {
+						continue
+					}
+					str = *null
+				case time.Time:
+					str = val.Format(time.RFC3339)
+				default:
+					str = aSeries.ValueString(row, dataframe.DontLock)
+				}
+
+				if asAttributes {
+					start.Attr = append(start.Attr, xml.Attr{Name: names[idx], Value: str})
+				} else {
+					children = append(children, xml.StartElement{Name: names[idx]})
+					values = append(values, str)
+				}
+			}
+
+			if err := enc.EncodeToken(start); err != nil {
+				return err
+			}
+			for i, child := range children {
+				if err := enc.EncodeElement(values[i], child); err != nil {
+					return err
+				}
+			}
+			if err := enc.EncodeToken(start.End()); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := enc.EncodeToken(root.End()); err != nil {
+		return err
+	}
+
+	return enc.Flush()
+}
+
+// xmlName converts name into a valid xml name.
+func xmlName(name string) string {
+
+	if name == "" {
+		return "_"
+	}
+
+	var b strings.Builder
+	for i, c := range name {
+		switch {
+		case c == '_' || unicode.IsLetter(c):
+		case i > 0 && (c == '-' || c == '.' || unicode.IsDigit(c)):
+		default:
+			c = '_'
+		}
+		b.WriteRune(c)
+	}
+
+	out := b.String()
+	if strings.HasPrefix(strings.ToLower(out), "xml") {
+		// Names beginning with "xml" are reserved
+		out = "_" + out
+	}
+	return out
+}
@@ -7,17 +7,22 @@ require (
 	github.com/DzananGanic/numericalgo v0.0.0-20170804125527-2b389385baf0
 	github.com/brianvoe/gofakeit/v4 v4.3.0
 	github.com/cnkei/gospline v0.0.0-20191204072713-842a72f86331
+	github.com/google/flatbuffers v2.0.8+incompatible
 	github.com/google/go-cmp v0.4.0
 	github.com/icza/gox v0.0.0-20200320174535-a6ff52ab3d90
+	github.com/klauspost/compress v1.9.7
+	github.com/linkedin/goavro/v2 v2.10.0
 	github.com/olekukonko/tablewriter v0.0.4
 	github.com/ompluscator/dynamic-struct v1.2.0
 	github.com/rocketlaunchr/mysql-go v1.1.3
 	github.com/tealeg/xlsx/v3 v3.0.0
+	github.com/ulikunitz/xz v0.5.12
 	github.com/wcharczuk/go-chart v2.0.1+incompatible
 	github.com/xitongsys/parquet-go v1.5.2
 	github.com/xitongsys/parquet-go-source v0.0.0-20200509081216-8db33acb0acf
 	github.com/zserge/lorca v0.1.9
 	golang.org/x/exp v0.0.0-20200331195152-e8c3332aa8e5
+	golang.org/x/net v0.0.0-20200501053045-e0ff5e5a1de5
 	golang.org/x/sync v0.0.0-20200317015054-43a5402ce75a
 	golang.org/x/xerrors v0.0.0-20191204190536-9bdfabe68543
 	gonum.org/v1/gonum v0.7.0
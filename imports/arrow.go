@@ -0,0 +1,680 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package imports
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"time"
+
+	flatbuffers "github.com/google/flatbuffers/go"
+	dataframe "github.com/rocketlaunchr/dataframe-go"
+)
+
+// Arrow metadata constants.
+//
+// See: https://github.com/apache/arrow/tree/master/format
+const (
+	arrowHeaderSchema          = 1
+	arrowHeaderDictionaryBatch = 2
+	arrowHeaderRecordBatch     = 3
+
+	arrowTypeInt           = 2
+	arrowTypeFloatingPoint = 3
+	arrowTypeUtf8          = 5
+	arrowTypeBool          = 6
+	arrowTypeDate          = 8
+	arrowTypeTimestamp     = 10
+	arrowTypeLargeUtf8     = 20
+)
+
+var arrowMagic = []byte("ARROW1")
+
+// ErrArrowInvalid signifies that the data is not in a valid Arrow IPC format.
+var ErrArrowInvalid = errors.New("invalid arrow data")
+
+type arrowField struct {
+	name      string
+	typ       byte
+	bitWidth  int32 // Int
+	signed    bool  // Int
+	precision int16 // FloatingPoint
+	unit      int16 // Date, Timestamp
+	loc       *time.Location
+
+	// Dictionary encoding
+	dictID    *int64
+	indexType *arrowField
+}
+
+// LoadFromArrow will load data from an Apache Arrow IPC stream or file.
+// The format is detected automatically.
+//
+// Integer and bool fields are imported as a SeriesInt64, floating point fields as a SeriesFloat64,
+// utf8 fields (including dictionary encoded utf8 fields) as a SeriesString and date and timestamp
+// fields as a SeriesTime. Null values are preserved.
+//
+// Compressed record batches are not supported.
+//
+// See: https://arrow.apache.org/docs/format/Columnar.html#serialization-and-interprocess-communication-ipc
+func LoadFromArrow(ctx context.Context, r io.ReadSeeker) (_ *dataframe.DataFrame, rErr error) {
+
+	// Malformed metadata can trigger out of bounds access
+	defer func() {
+		if x := recover(); x != nil {
+			rErr = ErrArrowInvalid
+		}
+	}()
+
+	magic := make([]byte, 8)
+	n, err := io.ReadFull(r, magic)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		if err == io.EOF {
+			return nil, dataframe.ErrNoRows
+		}
+		return nil, err
+	}
+
+	if n >= 6 && bytes.Equal(magic[:6], arrowMagic) {
+		return loadArrowFile(ctx, r)
+	}
+
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	return loadArrowStream(ctx, r)
+}
+
+func loadArrowStream(ctx context.Context, r io.Reader) (*dataframe.DataFrame, error) {
+
+	var (
+		fields  []*arrowField
+		seriess []dataframe.Series
+		dicts   = map[int64][]*string{}
+	)
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		msg, body, err := readArrowMessage(r)
+		if err != nil {
+			return nil, err
+		}
+		if msg == nil {
+			break // end-of-stream
+		}
+
+		var header flatbuffers.Table
+		if !fbUnion(msg, 2, &header) {
+			return nil, ErrArrowInvalid
+		}
+
+		switch msg.GetByteSlot(fbSlot(1), 0) {
+		case arrowHeaderSchema:
+			fields, err = parseArrowSchema(&header)
+			if err != nil {
+				return nil, err
+			}
+			seriess = newArrowSeries(fields)
+		case arrowHeaderDictionaryBatch:
+			if err := readArrowDictionary(&header, body, dicts); err != nil {
+				return nil, err
+			}
+		case arrowHeaderRecordBatch:
+			if seriess == nil {
+				return nil, ErrArrowInvalid
+			}
+			if err := readArrowRecordBatch(&header, body, fields, seriess, dicts); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if seriess == nil {
+		return nil, dataframe.ErrNoRows
+	}
+
+	return dataframe.NewDataFrame(seriess...), nil
+}
+
+func loadArrowFile(ctx context.Context, r io.ReadSeeker) (*dataframe.DataFrame, error) {
+
+	// Footer
+	if _, err := r.Seek(-10, io.SeekEnd); err != nil {
+		return nil, err
+	}
+	trailer := make([]byte, 10)
+	if _, err := io.ReadFull(r, trailer); err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(trailer[4:], arrowMagic) {
+		return nil, ErrArrowInvalid
+	}
+
+	footerLen := int64(binary.LittleEndian.Uint32(trailer))
+	if _, err := r.Seek(-10-footerLen, io.SeekEnd); err != nil {
+		return nil, err
+	}
+	buf := make([]byte, footerLen)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return nil, err
+	}
+	footer := fbRoot(buf)
+
+	var schema flatbuffers.Table
+	if !fbTable(footer, 1, &schema) {
+		return nil, ErrArrowInvalid
+	}
+
+	fields, err := parseArrowSchema(&schema)
+	if err != nil {
+		return nil, err
+	}
+	seriess := newArrowSeries(fields)
+	dicts := map[int64][]*string{}
+
+	// Blocks: {offset: long, metaDataLength: int, bodyLength: long}
+	readBlocks := func(slot int, f func(header *flatbuffers.Table, body []byte) error) error {
+		o := flatbuffers.UOffsetT(footer.Offset(fbSlot(slot)))
+		if o == 0 {
+			return nil
+		}
+		vec := footer.Vector(o)
+		for i := 0; i < footer.VectorLen(o); i++ {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			offset := footer.GetInt64(vec + flatbuffers.UOffsetT(24*i))
+			if _, err := r.Seek(offset, io.SeekStart); err != nil {
+				return err
+			}
+
+			msg, body, err := readArrowMessage(r)
+			if err != nil {
+				return err
+			}
+
+			var header flatbuffers.Table
+			if msg == nil || !fbUnion(msg, 2, &header) {
+				return ErrArrowInvalid
+			}
+
+			if err := f(&header, body); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	err = readBlocks(2, func(header *flatbuffers.Table, body []byte) error {
+		return readArrowDictionary(header, body, dicts)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = readBlocks(3, func(header *flatbuffers.Table, body []byte) error {
+		return readArrowRecordBatch(header, body, fields, seriess, dicts)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dataframe.NewDataFrame(seriess...), nil
+}
+
+// readArrowMessage reads an encapsulated message. A nil message signifies the end-of-stream.
+//
+// See: https://arrow.apache.org/docs/format/Columnar.html#encapsulated-message-format
+func readArrowMessage(r io.Reader) (*flatbuffers.Table, []byte, error) {
+
+	prefix := make([]byte, 4)
+	if _, err := io.ReadFull(r, prefix); err != nil {
+		if err == io.EOF {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+
+	metaLength := binary.LittleEndian.Uint32(prefix)
+	if metaLength == 0xFFFFFFFF {
+		// Continuation marker
+		if _, err := io.ReadFull(r, prefix); err != nil {
+			if err == io.EOF {
+				return nil, nil, nil
+			}
+			return nil, nil, err
+		}
+		metaLength = binary.LittleEndian.Uint32(prefix)
+	}
+
+	if metaLength == 0 {
+		return nil, nil, nil
+	}
+
+	meta, err := readArrowBytes(r, int64(metaLength))
+	if err != nil {
+		return nil, nil, err
+	}
+	msg := fbRoot(meta)
+
+	body, err := readArrowBytes(r, msg.GetInt64Slot(fbSlot(3), 0))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return msg, body, nil
+}
+
+// arrowMaxPrealloc is the largest length (in bytes) that readArrowBytes allocates before reading.
+const arrowMaxPrealloc = 1 << 20
+
+// readArrowBytes reads exactly n bytes from r. Since n is read from the data, it is not trusted:
+// large lengths are read incrementally so that memory usage is bound by the actual size of the data.
+func readArrowBytes(r io.Reader, n int64) ([]byte, error) {
+
+	if n < 0 {
+		return nil, ErrArrowInvalid
+	}
+
+	if n <= arrowMaxPrealloc {
+		b := make([]byte, n)
+		if _, err := io.ReadFull(r, b); err != nil {
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				return nil, ErrArrowInvalid
+			}
+			return nil, err
+		}
+		return b, nil
+	}
+
+	var buf bytes.Buffer
+	if _, err := io.CopyN(&buf, r, n); err != nil {
+		if err == io.EOF {
+			return nil, ErrArrowInvalid
+		}
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func parseArrowSchema(schema *flatbuffers.Table) ([]*arrowField, error) {
+
+	if schema.GetInt16Slot(fbSlot(0), 0) != 0 {
+		return nil, errors.New("big-endian arrow data is not supported")
+	}
+
+	fields := []*arrowField{}
+
+	o := flatbuffers.UOffsetT(schema.Offset(fbSlot(1)))
+	if o == 0 {
+		return fields, nil
+	}
+
+	vec := schema.Vector(o)
+	for i := 0; i < schema.VectorLen(o); i++ {
+		t := &flatbuffers.Table{Bytes: schema.Bytes, Pos: schema.Indirect(vec + flatbuffers.UOffsetT(4*i))}
+
+		f := &arrowField{
+			name: fbString(t, 0),
+			typ:  t.GetByteSlot(fbSlot(2), 0),
+		}
+
+		var typ flatbuffers.Table
+		if !fbUnion(t, 3, &typ) {
+			return nil, fmt.Errorf("arrow field has no type: %s", f.name)
+		}
+
+		switch f.typ {
+		case arrowTypeInt:
+			f.bitWidth = typ.GetInt32Slot(fbSlot(0), 0)
+			f.signed = typ.GetBoolSlot(fbSlot(1), false)
+		case arrowTypeFloatingPoint:
+			f.precision = typ.GetInt16Slot(fbSlot(0), 0)
+			if f.precision == 0 {
+				return nil, fmt.Errorf("half-precision arrow field is not supported: %s", f.name)
+			}
+		case arrowTypeUtf8, arrowTypeLargeUtf8, arrowTypeBool:
+		case arrowTypeDate:
+			f.unit = typ.GetInt16Slot(fbSlot(0), 1)
+			f.loc = time.UTC
+		case arrowTypeTimestamp:
+			f.unit = typ.GetInt16Slot(fbSlot(0), 0)
+			loc, err := arrowLocation(fbString(&typ, 1))
+			if err != nil {
+				return nil, err
+			}
+			f.loc = loc
+		default:
+			return nil, fmt.Errorf("arrow type (%d) is not supported: %s", f.typ, f.name)
+		}
+
+		var dict flatbuffers.Table
+		if fbTable(t, 4, &dict) {
+			if f.typ != arrowTypeUtf8 && f.typ != arrowTypeLargeUtf8 {
+				return nil, fmt.Errorf("dictionary encoded arrow field must be utf8: %s", f.name)
+			}
+
+			id := dict.GetInt64Slot(fbSlot(0), 0)
+			f.dictID = &id
+			f.indexType = &arrowField{typ: arrowTypeInt, bitWidth: 32, signed: true}
+
+			var indexType flatbuffers.Table
+			if fbTable(&dict, 1, &indexType) {
+				f.indexType.bitWidth = indexType.GetInt32Slot(fbSlot(0), 0)
+				f.indexType.signed = indexType.GetBoolSlot(fbSlot(1), false)
+			}
+		}
+
+		fields = append(fields, f)
+	}
+
+	return fields, nil
+}
+
+// arrowLocation interprets an arrow time zone, which can be an Olson name or an absolute offset.
+func arrowLocation(tz string) (*time.Location, error) {
+	if tz == "" {
+		return time.UTC, nil
+	}
+
+	if tz[0] == '+' || tz[0] == '-' {
+		t, err := time.Parse("-07:00", tz)
+		if err != nil {
+			return nil, fmt.Errorf("invalid arrow time zone: %s", tz)
+		}
+		_, offset := t.Zone()
+		return time.FixedZone(tz, offset), nil
+	}
+
+	return time.LoadLocation(tz)
+}
+
+func newArrowSeries(fields []*arrowField) []dataframe.Series {
+
+	seriess := []dataframe.Series{}
+
+	for _, f := range fields {
+		switch f.typ {
+		case arrowTypeInt:
+			seriess = append(seriess, dataframe.NewSeriesInt64(f.name, nil))
+		case arrowTypeBool:
+			s := dataframe.NewSeriesInt64(f.name, nil)
+			s.SetValueToStringFormatter(dataframe.BoolValueFormatter)
+			seriess = append(seriess, s)
+		case arrowTypeFloatingPoint:
+			seriess = append(seriess, dataframe.NewSeriesFloat64(f.name, nil))
+		case arrowTypeDate, arrowTypeTimestamp:
+			seriess = append(seriess, dataframe.NewSeriesTime(f.name, nil))
+		default:
+			seriess = append(seriess, dataframe.NewSeriesString(f.name, nil))
+		}
+	}
+
+	return seriess
+}
+
+func readArrowDictionary(header *flatbuffers.Table, body []byte, dicts map[int64][]*string) error {
+
+	id := header.GetInt64Slot(fbSlot(0), 0)
+
+	var rb flatbuffers.Table
+	if !fbTable(header, 1, &rb) {
+		return ErrArrowInvalid
+	}
+
+	s := dataframe.NewSeriesString("", nil)
+	f := &arrowField{typ: arrowTypeUtf8}
+
+	if err := readArrowRecordBatch(&rb, body, []*arrowField{f}, []dataframe.Series{s}, nil); err != nil {
+		return err
+	}
+
+	vals := []*string{}
+	for row := 0; row < s.NRows(dataframe.DontLock); row++ {
+		if v := s.Value(row, dataframe.DontLock); v != nil {
+			vals = append(vals, &[]string{v.(string)}[0])
+		} else {
+			vals = append(vals, nil)
+		}
+	}
+
+	if header.GetBoolSlot(fbSlot(2), false) {
+		// isDelta
+		dicts[id] = append(dicts[id], vals...)
+	} else {
+		dicts[id] = vals
+	}
+
+	return nil
+}
+
+func readArrowRecordBatch(rb *flatbuffers.Table, body []byte, fields []*arrowField, seriess []dataframe.Series, dicts map[int64][]*string) error {
+
+	if o := rb.Offset(fbSlot(3)); o != 0 {
+		return errors.New("compressed arrow data is not supported")
+	}
+
+	length := int(rb.GetInt64Slot(fbSlot(0), 0))
+
+	nodesOff := flatbuffers.UOffsetT(rb.Offset(fbSlot(1)))
+	bufsOff := flatbuffers.UOffsetT(rb.Offset(fbSlot(2)))
+	if nodesOff == 0 || bufsOff == 0 {
+		return ErrArrowInvalid
+	}
+	nodes := rb.Vector(nodesOff)
+	bufs := rb.Vector(bufsOff)
+	nBufs := rb.VectorLen(bufsOff)
+
+	var bufIdx int
+	nextBuf := func() ([]byte, error) {
+		if bufIdx >= nBufs {
+			return nil, ErrArrowInvalid
+		}
+		pos := bufs + flatbuffers.UOffsetT(16*bufIdx)
+		offset := rb.GetInt64(pos)
+		l := rb.GetInt64(pos + 8)
+		bufIdx++
+		if offset < 0 || l < 0 || offset+l > int64(len(body)) {
+			return nil, ErrArrowInvalid
+		}
+		return body[offset : offset+l], nil
+	}
+
+	for i, f := range fields {
+		nullCount := rb.GetInt64(nodes + flatbuffers.UOffsetT(16*i) + 8)
+
+		validity, err := nextBuf()
+		if err != nil {
+			return err
+		}
+		isNil := func(row int) bool {
+			if nullCount == 0 || len(validity) == 0 {
+				return false
+			}
+			return validity[row/8]&(1<<uint(row%8)) == 0
+		}
+
+		var data, offsets []byte
+		if f.dictID == nil && (f.typ == arrowTypeUtf8 || f.typ == arrowTypeLargeUtf8) {
+			if offsets, err = nextBuf(); err != nil {
+				return err
+			}
+		}
+		if data, err = nextBuf(); err != nil {
+			return err
+		}
+
+		switch s := seriess[i].(type) {
+		case *dataframe.SeriesInt64:
+			vals := make([]*int64, length)
+			for row := 0; row < length; row++ {
+				if isNil(row) {
+					continue
+				}
+				var v int64
+				if f.typ == arrowTypeBool {
+					v = int64(data[row/8] >> uint(row%8) & 1)
+				} else {
+					v = arrowInt(data, row, f.bitWidth, f.signed)
+				}
+				vals[row] = &v
+			}
+			s.Append(vals, dataframe.DontLock)
+		case *dataframe.SeriesFloat64:
+			var vals []float64
+			if f.precision == 2 {
+				// Double precision values are used directly from the buffer
+				vals = bytesFloat64(data[:8*length])
+			} else {
+				vals = make([]float64, length)
+				for row := 0; row < length; row++ {
+					vals[row] = float64(math.Float32frombits(binary.LittleEndian.Uint32(data[4*row:])))
+				}
+			}
+			for row := 0; row < length; row++ {
+				if isNil(row) {
+					vals[row] = math.NaN()
+				}
+			}
+			s.Append(vals, dataframe.DontLock)
+		case *dataframe.SeriesTime:
+			vals := make([]*time.Time, length)
+			for row := 0; row < length; row++ {
+				if isNil(row) {
+					continue
+				}
+				var t time.Time
+				if f.typ == arrowTypeDate {
+					if f.unit == 0 {
+						// days
+						t = time.Unix(int64(int32(binary.LittleEndian.Uint32(data[4*row:])))*86400, 0)
+					} else {
+						t = arrowTime(int64(binary.LittleEndian.Uint64(data[8*row:])), 1)
+					}
+				} else {
+					t = arrowTime(int64(binary.LittleEndian.Uint64(data[8*row:])), f.unit)
+				}
+				t = t.In(f.loc)
+				vals[row] = &t
+			}
+			s.Append(vals, dataframe.DontLock)
+		case *dataframe.SeriesString:
+			vals := make([]*string, length)
+			if f.dictID != nil {
+				dict := dicts[*f.dictID]
+				for row := 0; row < length; row++ {
+					if isNil(row) {
+						continue
+					}
+					idx := arrowInt(data, row, f.indexType.bitWidth, f.indexType.signed)
+					if idx < 0 || idx >= int64(len(dict)) {
+						return ErrArrowInvalid
+					}
+					vals[row] = dict[idx]
+				}
+			} else {
+				for row := 0; row < length; row++ {
+					if isNil(row) {
+						continue
+					}
+					var start, end int64
+					if f.typ == arrowTypeLargeUtf8 {
+						start = int64(binary.LittleEndian.Uint64(offsets[8*row:]))
+						end = int64(binary.LittleEndian.Uint64(offsets[8*(row+1):]))
+					} else {
+						start = int64(binary.LittleEndian.Uint32(offsets[4*row:]))
+						end = int64(binary.LittleEndian.Uint32(offsets[4*(row+1):]))
+					}
+					vals[row] = &[]string{string(data[start:end])}[0]
+				}
+			}
+			s.Append(vals, dataframe.DontLock)
+		}
+	}
+
+	return nil
+}
+
+func arrowInt(data []byte, row int, bitWidth int32, signed bool) int64 {
+	switch bitWidth {
+	case 8:
+		if signed {
+			return int64(int8(data[row]))
+		}
+		return int64(data[row])
+	case 16:
+		v := binary.LittleEndian.Uint16(data[2*row:])
+		if signed {
+			return int64(int16(v))
+		}
+		return int64(v)
+	case 32:
+		v := binary.LittleEndian.Uint32(data[4*row:])
+		if signed {
+			return int64(int32(v))
+		}
+		return int64(v)
+	default:
+		return int64(binary.LittleEndian.Uint64(data[8*row:]))
+	}
+}
+
+// arrowTime converts a timestamp of a given TimeUnit to a time.Time.
+func arrowTime(v int64, unit int16) time.Time {
+	switch unit {
+	case 0: // SECOND
+		return time.Unix(v, 0)
+	case 1: // MILLISECOND
+		return time.Unix(0, v*int64(time.Millisecond))
+	case 2: // MICROSECOND
+		return time.Unix(0, v*int64(time.Microsecond))
+	default: // NANOSECOND
+		return time.Unix(0, v)
+	}
+}
+
+/* flatbuffers helpers */
+
+func fbSlot(field int) flatbuffers.VOffsetT {
+	return flatbuffers.VOffsetT(4 + 2*field)
+}
+
+func fbRoot(buf []byte) *flatbuffers.Table {
+	return &flatbuffers.Table{Bytes: buf, Pos: flatbuffers.GetUOffsetT(buf)}
+}
+
+func fbTable(t *flatbuffers.Table, field int, out *flatbuffers.Table) bool {
+	o := flatbuffers.UOffsetT(t.Offset(fbSlot(field)))
+	if o == 0 {
+		return false
+	}
+	out.Bytes = t.Bytes
+	out.Pos = t.Indirect(o + t.Pos)
+	return true
+}
+
+func fbUnion(t *flatbuffers.Table, field int, out *flatbuffers.Table) bool {
+	o := flatbuffers.UOffsetT(t.Offset(fbSlot(field)))
+	if o == 0 {
+		return false
+	}
+	t.Union(out, o)
+	return true
+}
+
+func fbString(t *flatbuffers.Table, field int) string {
+	o := flatbuffers.UOffsetT(t.Offset(fbSlot(field)))
+	if o == 0 {
+		return ""
+	}
+	return t.String(o + t.Pos)
+}
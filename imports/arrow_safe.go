@@ -0,0 +1,19 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+// +build js appengine safe !386,!amd64,!amd64p32,!arm,!arm64,!mipsle,!mips64le,!mips64p32le,!ppc64le,!riscv64,!wasm
+
+package imports
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// bytesFloat64 decodes b as little-endian float64 values.
+func bytesFloat64(b []byte) []float64 {
+	f := make([]float64, len(b)/8)
+	for i := range f {
+		f[i] = math.Float64frombits(binary.LittleEndian.Uint64(b[8*i:]))
+	}
+	return f
+}
@@ -0,0 +1,122 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package imports
+
+import (
+	"bytes"
+	"os"
+	"testing"
+	"time"
+
+	dataframe "github.com/rocketlaunchr/dataframe-go"
+	"github.com/rocketlaunchr/dataframe-go/exports"
+)
+
+func TestArrowRoundTrip(t *testing.T) {
+
+	loc, _ := time.LoadLocation("Australia/Sydney")
+	tm := time.Date(2020, 1, 2, 3, 4, 5, 6, loc)
+
+	df := dataframe.NewDataFrame(
+		dataframe.NewSeriesInt64("int", nil, 1, nil, 3),
+		dataframe.NewSeriesFloat64("float", nil, 1.5, nil, 3.5),
+		dataframe.NewSeriesString("str", nil, "a", nil, "ccc"),
+		dataframe.NewSeriesString("category", nil, "x", "y", "x"),
+		dataframe.NewSeriesTime("time", nil, tm, nil, tm.Add(time.Hour)),
+	)
+
+	for _, fileFormat := range []bool{false, true} {
+		var buf bytes.Buffer
+
+		opts := exports.ArrowExportOptions{
+			FileFormat: fileFormat,
+			BatchSize:  2,
+			Dictionary: []string{"category"},
+		}
+
+		if err := exports.ExportToArrow(ctx, &buf, df, opts); err != nil {
+			t.Fatalf("arrow export error: %v", err)
+		}
+
+		df2, err := LoadFromArrow(ctx, bytes.NewReader(buf.Bytes()))
+		if err != nil {
+			t.Fatalf("arrow import error: %v", err)
+		}
+
+		if eq, err := df.IsEqual(ctx, df2, dataframe.IsEqualOptions{CheckName: true}); !eq {
+			t.Errorf("arrow round trip not equal (file format: %v): %v\n%v", fileFormat, err, df2.Table())
+		}
+
+		if tz := df2.Series[4].Value(0).(time.Time).Location().String(); tz != "Australia/Sydney" {
+			t.Errorf("arrow time zone not preserved: %s", tz)
+		}
+	}
+}
+
+// The golden files in testdata were produced by the Go implementation of Apache Arrow (arrow/go/v12).
+// arrowgo.arrow contains 2 record batches and arrowgo.stream contains 1.
+func TestArrowGolden(t *testing.T) {
+
+	ny, _ := time.LoadLocation("America/New_York")
+	tm := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC).In(ny)
+	dt := time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	batch := dataframe.NewDataFrame(
+		dataframe.NewSeriesInt64("i", nil, 1, nil, 3),
+		dataframe.NewSeriesFloat64("f", nil, 1.5, 2.5, nil),
+		dataframe.NewSeriesString("s", nil, "a", nil, "ccc"),
+		dataframe.NewSeriesTime("t", nil, tm, nil, tm.Add(time.Second)),
+		dataframe.NewSeriesString("d", nil, "x", nil, "x"),
+		dataframe.NewSeriesInt64("b", nil, 1, 0, 1),
+		dataframe.NewSeriesInt64("l", nil, -1<<40, 0, nil),
+		dataframe.NewSeriesTime("dt", nil, dt, nil, dt.AddDate(0, 0, 1)),
+		dataframe.NewSeriesString("ls", nil, "", "large", "z"),
+	)
+
+	tests := []struct {
+		file    string
+		batches int
+	}{
+		{"testdata/arrowgo.arrow", 2},
+		{"testdata/arrowgo.stream", 1},
+	}
+
+	for _, tc := range tests {
+		f, err := os.Open(tc.file)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.file, err)
+		}
+
+		df, err := LoadFromArrow(ctx, f)
+		f.Close()
+		if err != nil {
+			t.Fatalf("%s: arrow import error: %v", tc.file, err)
+		}
+
+		expected := batch.Copy()
+		for i := 1; i < tc.batches; i++ {
+			for row := 0; row < batch.NRows(); row++ {
+				expected.Append(nil, batch.Row(row, false, dataframe.SeriesName))
+			}
+		}
+
+		if eq, err := expected.IsEqual(ctx, df, dataframe.IsEqualOptions{CheckName: true}); !eq {
+			t.Errorf("%s: not equal: %v\n%v", tc.file, err, df.Table())
+		}
+
+		if tz := df.Series[3].Value(0).(time.Time).Location().String(); tz != "America/New_York" {
+			t.Errorf("%s: time zone not preserved: %s", tc.file, tz)
+		}
+	}
+}
+
+func TestArrowInvalid(t *testing.T) {
+
+	// Stream message claiming a huge body
+	var buf bytes.Buffer
+	buf.Write([]byte{0xFF, 0xFF, 0xFF, 0xFF, 0x00, 0x00, 0x00, 0x7F})
+
+	if _, err := LoadFromArrow(ctx, bytes.NewReader(buf.Bytes())); err == nil {
+		t.Errorf("expected error for truncated arrow data")
+	}
+}
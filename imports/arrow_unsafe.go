@@ -0,0 +1,36 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+// +build !js,!appengine,!safe
+// +build 386 amd64 amd64p32 arm arm64 mipsle mips64le mips64p32le ppc64le riscv64 wasm
+
+package imports
+
+import (
+	"encoding/binary"
+	"math"
+	"reflect"
+	"unsafe"
+)
+
+// bytesFloat64 returns b as a []float64. When b is suitably aligned, the underlying
+// memory is shared and no copy is made. It is only built for little-endian architectures.
+func bytesFloat64(b []byte) []float64 {
+	if len(b) == 0 {
+		return []float64{}
+	}
+
+	if uintptr(unsafe.Pointer(&b[0]))%8 != 0 {
+		f := make([]float64, len(b)/8)
+		for i := range f {
+			f[i] = math.Float64frombits(binary.LittleEndian.Uint64(b[8*i:]))
+		}
+		return f
+	}
+
+	var f []float64
+	hdr := (*reflect.SliceHeader)(unsafe.Pointer(&f))
+	hdr.Data = uintptr(unsafe.Pointer(&b[0]))
+	hdr.Len = len(b) / 8
+	hdr.Cap = len(b) / 8
+	return f
+}
@@ -0,0 +1,185 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package imports
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"math/big"
+	"time"
+
+	"github.com/linkedin/goavro/v2"
+	dataframe "github.com/rocketlaunchr/dataframe-go"
+)
+
+// LoadFromAvro will load data from an Avro Object Container File.
+// The schema must be a record type.
+//
+// Fields are mapped to Series based on their Avro type:
+//
+//  int, long, boolean                               -> SeriesInt64
+//  float, double, decimal                           -> SeriesFloat64
+//  string, enum, bytes, fixed                       -> SeriesString
+//  date, timestamp-millis, timestamp-micros         -> SeriesTime
+//  time-millis, time-micros                         -> SeriesDuration (time since midnight)
+//  all other types (eg. array, map, record)         -> SeriesMixed
+//
+// A union of null and another type is mapped to a nullable Series of the other type.
+//
+// See: https://avro.apache.org/docs/current/spec.html#Object+Container+Files
+func LoadFromAvro(ctx context.Context, r io.ReadSeeker) (*dataframe.DataFrame, error) {
+
+	ocfr, err := goavro.NewOCFReader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var schema map[string]interface{}
+	if err := json.Unmarshal([]byte(ocfr.Codec().Schema()), &schema); err != nil {
+		return nil, err
+	}
+
+	if schema["type"] != "record" {
+		return nil, errors.New("avro schema must be a record")
+	}
+
+	// Create the series
+	names := []string{}
+	types := []interface{}{}
+	seriess := []dataframe.Series{}
+
+	fields, _ := schema["fields"].([]interface{})
+	for _, f := range fields {
+		fm, ok := f.(map[string]interface{})
+		if !ok {
+			return nil, errors.New("invalid avro schema field")
+		}
+
+		name, _ := fm["name"].(string)
+		names = append(names, name)
+		types = append(types, fm["type"])
+		seriess = append(seriess, newAvroSeries(name, fm["type"]))
+	}
+
+	df := dataframe.NewDataFrame(seriess...)
+
+	for ocfr.Scan() {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		datum, err := ocfr.Read()
+		if err != nil {
+			return nil, err
+		}
+
+		record, ok := datum.(map[string]interface{})
+		if !ok {
+			return nil, errors.New("avro datum is not a record")
+		}
+
+		// Keyed by column so that a map value in the first column is not mistaken for a row
+		insertVals := make(map[interface{}]interface{}, len(names))
+		for i, name := range names {
+			insertVals[i] = avroValue(record[name], types[i])
+		}
+		df.Append(&dataframe.DontLock, insertVals)
+	}
+
+	if err := ocfr.Err(); err != nil {
+		return nil, err
+	}
+
+	return df, nil
+}
+
+func newAvroSeries(name string, typ interface{}) dataframe.Series {
+
+	switch t := typ.(type) {
+	case string:
+		switch t {
+		case "int", "long":
+			return dataframe.NewSeriesInt64(name, nil)
+		case "boolean":
+			s := dataframe.NewSeriesInt64(name, nil)
+			s.SetValueToStringFormatter(dataframe.BoolValueFormatter)
+			return s
+		case "float", "double":
+			return dataframe.NewSeriesFloat64(name, nil)
+		case "string", "bytes":
+			return dataframe.NewSeriesString(name, nil)
+		}
+	case []interface{}:
+		// union of null and another type
+		var other interface{}
+		for _, m := range t {
+			if m == "null" {
+				continue
+			}
+			if other != nil {
+				return dataframe.NewSeriesMixed(name, nil)
+			}
+			other = m
+		}
+		if other != nil {
+			return newAvroSeries(name, other)
+		}
+	case map[string]interface{}:
+		switch t["logicalType"] {
+		case "date", "timestamp-millis", "timestamp-micros":
+			return dataframe.NewSeriesTime(name, nil)
+		case "time-millis", "time-micros":
+			return dataframe.NewSeriesDuration(name, nil)
+		case "decimal":
+			return dataframe.NewSeriesFloat64(name, nil)
+		}
+
+		switch t["type"] {
+		case "enum", "fixed":
+			return dataframe.NewSeriesString(name, nil)
+		case "array", "map", "record":
+			return dataframe.NewSeriesMixed(name, nil)
+		default:
+			return newAvroSeries(name, t["type"])
+		}
+	}
+
+	return dataframe.NewSeriesMixed(name, nil)
+}
+
+// avroValue converts a native goavro value (of Avro type typ) to a value that can be inserted into a Series.
+func avroValue(v interface{}, typ interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		// goavro encodes a non-null union value as a map containing the member's name and value.
+		// Unions can't directly contain unions, so the member value is not unwrapped again.
+		if _, isUnion := typ.([]interface{}); isUnion && len(val) == 1 {
+			for _, uv := range val {
+				return avroValue(uv, nil)
+			}
+		}
+		return val
+	case int32:
+		return int64(val)
+	case int64:
+		return val
+	case bool:
+		if val {
+			return int64(1)
+		}
+		return int64(0)
+	case float32:
+		return float64(val)
+	case []byte:
+		return string(val)
+	case *big.Rat:
+		f, _ := val.Float64()
+		return f
+	case time.Time, time.Duration:
+		return val
+	default:
+		return val
+	}
+}
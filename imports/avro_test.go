@@ -0,0 +1,204 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package imports
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/linkedin/goavro/v2"
+	dataframe "github.com/rocketlaunchr/dataframe-go"
+	"github.com/rocketlaunchr/dataframe-go/exports"
+)
+
+func TestAvroRoundTrip(t *testing.T) {
+
+	tm := time.Date(2020, 1, 2, 3, 4, 5, 6000, time.UTC)
+
+	df := dataframe.NewDataFrame(
+		dataframe.NewSeriesInt64("int", nil, 1, nil, 3),
+		dataframe.NewSeriesFloat64("float", nil, 1.5, nil, 3.5),
+		dataframe.NewSeriesString("str", nil, "a", nil, "ccc"),
+		dataframe.NewSeriesTime("time", nil, tm, nil, tm.Add(time.Hour)),
+	)
+
+	for _, compression := range []exports.AvroCompression{exports.AvroNull, exports.AvroDeflate, exports.AvroSnappy} {
+		var buf bytes.Buffer
+
+		if err := exports.ExportToAvro(ctx, &buf, df, exports.AvroExportOptions{Compression: compression}); err != nil {
+			t.Fatalf("avro export error: %v", err)
+		}
+
+		df2, err := LoadFromAvro(ctx, bytes.NewReader(buf.Bytes()))
+		if err != nil {
+			t.Fatalf("avro import error: %v", err)
+		}
+
+		if eq, err := df.IsEqual(ctx, df2, dataframe.IsEqualOptions{CheckName: true}); !eq {
+			t.Errorf("avro round trip not equal (%s): %v\n%v", compression, err, df2.Table())
+		}
+	}
+}
+
+func TestAvroSchema(t *testing.T) {
+
+	schema := `{
+		"type": "record",
+		"name": "Sale",
+		"fields": [
+			{"name": "id", "type": "long"},
+			{"name": "amount", "type": ["null", {"type": "bytes", "logicalType": "decimal", "precision": 10, "scale": 2}]},
+			{"name": "at", "type": {"type": "long", "logicalType": "timestamp-millis"}}
+		]
+	}`
+
+	tm := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	df := dataframe.NewDataFrame(
+		dataframe.NewSeriesInt64("id", nil, 1, 2),
+		dataframe.NewSeriesFloat64("amount", nil, 12.25, nil),
+		dataframe.NewSeriesTime("at", nil, tm, tm),
+	)
+
+	var buf bytes.Buffer
+	if err := exports.ExportToAvro(ctx, &buf, df, exports.AvroExportOptions{Schema: &schema}); err != nil {
+		t.Fatalf("avro export error: %v", err)
+	}
+
+	df2, err := LoadFromAvro(ctx, bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("avro import error: %v", err)
+	}
+
+	if eq, err := df.IsEqual(ctx, df2, dataframe.IsEqualOptions{CheckName: true}); !eq {
+		t.Errorf("avro not equal: %v\n%v", err, df2.Table())
+	}
+}
+
+func TestAvroLogicalTypes(t *testing.T) {
+
+	schema := `{
+		"type": "record",
+		"name": "Event",
+		"fields": [
+			{"name": "ts_millis", "type": {"type": "long", "logicalType": "timestamp-millis"}},
+			{"name": "ts_micros", "type": ["null", {"type": "long", "logicalType": "timestamp-micros"}]},
+			{"name": "date", "type": ["null", {"type": "int", "logicalType": "date"}]},
+			{"name": "time_millis", "type": {"type": "int", "logicalType": "time-millis"}},
+			{"name": "time_micros", "type": ["null", {"type": "long", "logicalType": "time-micros"}]},
+			{"name": "decimal", "type": ["null", {"type": "bytes", "logicalType": "decimal", "precision": 10, "scale": 2}]}
+		]
+	}`
+
+	tm := time.Date(2020, 1, 2, 3, 4, 5, 6000, time.UTC)
+	date := time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)
+	tod := 3*time.Hour + 4*time.Minute + 5*time.Second
+
+	df := dataframe.NewDataFrame(
+		dataframe.NewSeriesTime("ts_millis", nil, tm.Truncate(time.Millisecond), tm.Add(time.Hour).Truncate(time.Millisecond)),
+		dataframe.NewSeriesTime("ts_micros", nil, tm, nil),
+		dataframe.NewSeriesTime("date", nil, date, nil),
+		dataframe.NewSeriesTime("time_millis", nil, tm, tm.Add(time.Hour)), // exported as time since midnight
+		dataframe.NewSeriesDuration("time_micros", nil, tod+6*time.Microsecond, nil),
+		dataframe.NewSeriesFloat64("decimal", nil, 12.25, nil),
+	)
+
+	var buf bytes.Buffer
+	if err := exports.ExportToAvro(ctx, &buf, df, exports.AvroExportOptions{Schema: &schema}); err != nil {
+		t.Fatalf("avro export error: %v", err)
+	}
+
+	df2, err := LoadFromAvro(ctx, bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("avro import error: %v", err)
+	}
+
+	expected := dataframe.NewDataFrame(
+		df.Series[0],
+		df.Series[1],
+		df.Series[2],
+		dataframe.NewSeriesDuration("time_millis", nil, tod, tod+time.Hour),
+		df.Series[4],
+		df.Series[5],
+	)
+
+	if eq, err := expected.IsEqual(ctx, df2, dataframe.IsEqualOptions{CheckName: true}); !eq {
+		t.Errorf("avro not equal: %v\n%v", err, df2.Table())
+	}
+
+	// Export the imported DataFrame again
+	buf.Reset()
+	if err := exports.ExportToAvro(ctx, &buf, df2, exports.AvroExportOptions{Schema: &schema}); err != nil {
+		t.Fatalf("avro export error: %v", err)
+	}
+
+	df3, err := LoadFromAvro(ctx, bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("avro import error: %v", err)
+	}
+
+	if eq, err := df2.IsEqual(ctx, df3, dataframe.IsEqualOptions{CheckName: true}); !eq {
+		t.Errorf("avro round trip not equal: %v\n%v", err, df3.Table())
+	}
+}
+
+func TestAvroMapAndRecord(t *testing.T) {
+
+	schema := `{
+		"type": "record",
+		"name": "Row",
+		"fields": [
+			{"name": "m", "type": {"type": "map", "values": "long"}},
+			{"name": "r", "type": {"type": "record", "name": "Inner", "fields": [{"name": "x", "type": "long"}]}},
+			{"name": "n", "type": ["null", {"type": "map", "values": "long"}]}
+		]
+	}`
+
+	codec, err := goavro.NewCodec(schema)
+	if err != nil {
+		t.Fatalf("codec error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	w, err := goavro.NewOCFWriter(goavro.OCFConfig{W: &buf, Codec: codec})
+	if err != nil {
+		t.Fatalf("writer error: %v", err)
+	}
+
+	records := []interface{}{
+		map[string]interface{}{
+			"m": map[string]interface{}{"a": int64(1)},
+			"r": map[string]interface{}{"x": int64(2)},
+			"n": goavro.Union("map", map[string]interface{}{"b": int64(3)}),
+		},
+		map[string]interface{}{
+			"m": map[string]interface{}{"a": int64(1), "b": int64(2)},
+			"r": map[string]interface{}{"x": int64(4)},
+			"n": nil,
+		},
+	}
+	if err := w.Append(records); err != nil {
+		t.Fatalf("append error: %v", err)
+	}
+
+	df, err := LoadFromAvro(ctx, bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("avro import error: %v", err)
+	}
+
+	expected := [][]interface{}{
+		{map[string]interface{}{"a": int64(1)}, map[string]interface{}{"a": int64(1), "b": int64(2)}},
+		{map[string]interface{}{"x": int64(2)}, map[string]interface{}{"x": int64(4)}},
+		{map[string]interface{}{"b": int64(3)}, nil},
+	}
+
+	for i, s := range df.Series {
+		for row, exp := range expected[i] {
+			if actual := s.Value(row); !reflect.DeepEqual(actual, exp) {
+				t.Errorf("wrong val for %s (row %d): expected: %v actual: %v", s.Name(), row, exp, actual)
+			}
+		}
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package imports
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+
+	dataframe "github.com/rocketlaunchr/dataframe-go"
+)
+
+// LoadFromBinary will load data produced by exports.ExportToBinary
+// (or DataFrame's MarshalBinary method).
+//
+// Custom Series must be registered with dataframe.RegisterSeries.
+func LoadFromBinary(ctx context.Context, r io.Reader) (*dataframe.DataFrame, error) {
+
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	df := dataframe.NewDataFrame()
+	if err := df.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+
+	return df, nil
+}
@@ -0,0 +1,70 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package imports
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	dataframe "github.com/rocketlaunchr/dataframe-go"
+	"github.com/rocketlaunchr/dataframe-go/exports"
+)
+
+func TestBinaryRoundTrip(t *testing.T) {
+
+	tm := time.Date(2020, 1, 2, 3, 4, 5, 6, time.UTC)
+
+	tests := []struct {
+		df *dataframe.DataFrame
+		r  dataframe.Range
+	}{
+		{
+			dataframe.NewDataFrame(
+				dataframe.NewSeriesInt64("int", nil),
+				dataframe.NewSeriesString("str", nil),
+			),
+			dataframe.Range{},
+		},
+		{
+			dataframe.NewDataFrame(
+				dataframe.NewSeriesInt64("int", nil, 1, nil, 3),
+				dataframe.NewSeriesFloat64("float", nil, nil, 2.5, 3.5),
+				dataframe.NewSeriesString("str", nil, "a", nil, "ccc"),
+				dataframe.NewSeriesTime("time", nil, tm, nil, tm.Add(time.Hour)),
+				dataframe.NewSeriesDuration("duration", nil, nil, time.Second, time.Hour),
+				dataframe.NewSeriesMixed("mixed", nil, 1, "b", nil),
+			),
+			dataframe.Range{},
+		},
+		{
+			dataframe.NewDataFrame(
+				dataframe.NewSeriesInt64("int", nil, 1, nil, 3),
+				dataframe.NewSeriesString("str", nil, "a", nil, "ccc"),
+			),
+			dataframe.RangeFinite(1, 2),
+		},
+	}
+
+	for i, tc := range tests {
+		var buf bytes.Buffer
+
+		if err := exports.ExportToBinary(ctx, &buf, tc.df, exports.BinaryExportOptions{Range: tc.r}); err != nil {
+			t.Fatalf("%d: binary export error: %v", i, err)
+		}
+
+		df, err := LoadFromBinary(ctx, &buf)
+		if err != nil {
+			t.Fatalf("%d: binary import error: %v", i, err)
+		}
+
+		expected := tc.df
+		if tc.df.NRows() > 0 {
+			expected = tc.df.Copy(tc.r)
+		}
+
+		if eq, err := expected.IsEqual(ctx, df, dataframe.IsEqualOptions{CheckName: true}); !eq {
+			t.Errorf("%d: binary round trip not equal: %v\n%v", i, err, df.Table())
+		}
+	}
+}
@@ -175,6 +175,27 @@ func dictateForce(row int, insertVals map[string]interface{}, name string, typ i
 		default:
 			return fmt.Errorf("can't force %T to time.Time. row: %d field: %s", v, row-1, name)
 		}
+	case time.Duration:
+		// Force v to duration
+		switch v := val.(type) {
+		case string:
+			d, err := time.ParseDuration(v)
+			if err != nil {
+				return fmt.Errorf("can't force string: %s to time.Duration. row: %d field: %s", v, row-1, name)
+			}
+			insertVals[name] = d
+		case json.Number:
+			// Assume nanoseconds
+			ns, err := v.Int64()
+			if err != nil {
+				return fmt.Errorf("can't force number to int64 (nanoseconds). row: %d field: %s", row-1, name)
+			}
+			insertVals[name] = time.Duration(ns)
+		case nil:
+			// Do nothing
+		default:
+			return fmt.Errorf("can't force %T to time.Duration. row: %d field: %s", v, row-1, name)
+		}
 	case dataframe.NewSerieser:
 		// Force v to string
 		switch v := val.(type) {
@@ -203,3 +224,85 @@ func dictateForce(row int, insertVals map[string]interface{}, name string, typ i
 
 	return nil
 }
+
+// timeString converts a time.Time value to a string (in RFC3339 format).
+// All other values are returned unchanged.
+func timeString(v interface{}) interface{} {
+	switch v := v.(type) {
+	case time.Time:
+		return v.Format(time.RFC3339)
+	default:
+		return v
+	}
+}
+
+// dictatedSeries creates a Series based on the dictated data type and appends vals.
+// vals must be nil, a string or a time.Time.
+func dictatedSeries(name string, typ interface{}, vals []interface{}, init *dataframe.SeriesInit) (dataframe.Series, error) {
+
+	var s dataframe.Series
+
+	switch T := typ.(type) {
+	case float64:
+		s = dataframe.NewSeriesFloat64(name, init)
+	case int64, bool:
+		s = dataframe.NewSeriesInt64(name, init)
+	case string:
+		s = dataframe.NewSeriesString(name, init)
+	case time.Time:
+		s = dataframe.NewSeriesTime(name, init)
+	case time.Duration:
+		s = dataframe.NewSeriesDuration(name, init)
+	case dataframe.NewSerieser:
+		s = T.NewSeries(name, init)
+	case Converter:
+		switch T.ConcreteType.(type) {
+		case time.Time:
+			s = dataframe.NewSeriesTime(name, init)
+		default:
+			s = dataframe.NewSeriesGeneric(name, T.ConcreteType, init)
+		}
+	default:
+		s = dataframe.NewSeriesGeneric(name, typ, init)
+	}
+
+	insertVals := map[string]interface{}{}
+
+	for row, v := range vals {
+		if v == nil {
+			s.Append(nil, dataframe.DontLock)
+			continue
+		}
+
+		if t, ok := v.(time.Time); ok {
+			switch T := typ.(type) {
+			case time.Time:
+				s.Append(t, dataframe.DontLock)
+				continue
+			case Converter:
+				cv, err := T.ConverterFunc(t)
+				if err != nil {
+					return nil, fmt.Errorf("can't force %T to generic data type. row: %d field: %s", t, row, name)
+				}
+				s.Append(cv, dataframe.DontLock)
+				continue
+			}
+		}
+
+		switch typ.(type) {
+		case float64, int64, bool, string, time.Time, time.Duration, dataframe.NewSerieser, Converter:
+		default:
+			s.Append(timeString(v), dataframe.DontLock)
+			continue
+		}
+
+		// row+1 because dictateForce reports errors with a 1-based row
+		err := dictateForce(row+1, insertVals, name, typ, timeString(v))
+		if err != nil {
+			return nil, err
+		}
+		s.Append(insertVals[name], dataframe.DontLock)
+	}
+
+	return s, nil
+}
@@ -0,0 +1,163 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package imports
+
+import (
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"errors"
+	"io"
+	"io/ioutil"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/ulikunitz/xz"
+)
+
+// compressionFormats maps the magic bytes of each supported compression format
+// to a function that creates a decompressing reader.
+var compressionFormats = []struct {
+	magic     []byte
+	newReader func(r io.Reader) (io.Reader, error)
+}{
+	{
+		// gzip
+		magic: []byte{0x1f, 0x8b},
+		newReader: func(r io.Reader) (io.Reader, error) {
+			return gzip.NewReader(r)
+		},
+	},
+	{
+		// bzip2
+		magic: []byte("BZh"),
+		newReader: func(r io.Reader) (io.Reader, error) {
+			return bzip2.NewReader(r), nil
+		},
+	},
+	{
+		// zstd
+		magic: []byte{0x28, 0xb5, 0x2f, 0xfd},
+		newReader: func(r io.Reader) (io.Reader, error) {
+			return zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+		},
+	},
+	{
+		// xz
+		magic: []byte{0xfd, '7', 'z', 'X', 'Z', 0x00},
+		newReader: func(r io.Reader) (io.Reader, error) {
+			return xz.NewReader(r)
+		},
+	},
+}
+
+// decompress detects if the data of r is compressed (gzip, bzip2, zstd or xz) using the magic bytes.
+// If it is, a ReadSeeker of the decompressed data is returned. Otherwise r is returned.
+func decompress(r io.ReadSeeker) (io.ReadSeeker, error) {
+
+	start, err := r.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, 6)
+	n, err := io.ReadFull(r, header)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return nil, err
+	}
+	header = header[:n]
+
+	if _, err := r.Seek(start, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	for _, f := range compressionFormats {
+		if bytes.HasPrefix(header, f.magic) {
+			return &decompressReader{src: r, start: start, newReader: f.newReader}, nil
+		}
+	}
+
+	return r, nil
+}
+
+// decompressReader provides limited seeking ability for compressed data.
+// Seeking backwards requires the data to be decompressed again from the start.
+type decompressReader struct {
+	src       io.ReadSeeker
+	start     int64 // position of compressed data within src
+	newReader func(r io.Reader) (io.Reader, error)
+
+	rd  io.Reader
+	eof bool
+	pos int64 // position within decompressed data
+}
+
+func (d *decompressReader) Read(p []byte) (int, error) {
+	if d.eof {
+		return 0, io.EOF
+	}
+
+	if d.rd == nil {
+		rd, err := d.newReader(d.src)
+		if err != nil {
+			return 0, err
+		}
+		d.rd = rd
+	}
+
+	n, err := d.rd.Read(p)
+	d.pos += int64(n)
+	if err == io.EOF {
+		d.eof = true
+		d.close()
+	}
+	return n, err
+}
+
+// Seek implements the io.Seeker interface. io.SeekEnd is not supported.
+func (d *decompressReader) Seek(offset int64, whence int) (int64, error) {
+
+	var abs int64
+	switch whence {
+	case io.SeekStart:
+		abs = offset
+	case io.SeekCurrent:
+		abs = d.pos + offset
+	default:
+		return 0, errors.New("seek not supported on compressed data")
+	}
+
+	if abs < 0 {
+		return 0, errors.New("negative position")
+	}
+
+	if abs < d.pos {
+		// Start again
+		if !d.eof {
+			d.close()
+		}
+		if _, err := d.src.Seek(d.start, io.SeekStart); err != nil {
+			return 0, err
+		}
+		d.rd = nil
+		d.eof = false
+		d.pos = 0
+	}
+
+	if abs > d.pos {
+		if _, err := io.CopyN(ioutil.Discard, d, abs-d.pos); err != nil && err != io.EOF {
+			return 0, err
+		}
+	}
+
+	return d.pos, nil
+}
+
+// close releases resources held by the decompressor.
+func (d *decompressReader) close() {
+	switch rd := d.rd.(type) {
+	case *zstd.Decoder:
+		rd.Close()
+	case io.Closer:
+		rd.Close()
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package imports
+
+import (
+	"bytes"
+	"encoding/base64"
+	"testing"
+
+	dataframe "github.com/rocketlaunchr/dataframe-go"
+	"github.com/rocketlaunchr/dataframe-go/exports"
+)
+
+func TestCompression(t *testing.T) {
+
+	df := dataframe.NewDataFrame(
+		dataframe.NewSeriesString("a", nil, "1", "2"),
+		dataframe.NewSeriesString("b", nil, "x", "y"),
+	)
+
+	for _, c := range []exports.Compression{exports.NoCompression, exports.Gzip, exports.Zstd, exports.XZ} {
+
+		// CSV (with LargeDataSet pre-scan)
+		var buf bytes.Buffer
+		if err := exports.ExportToCSV(ctx, &buf, df, exports.CSVExportOptions{Separator: ',', Compression: c}); err != nil {
+			t.Fatalf("%s: csv export error: %v", c, err)
+		}
+
+		df2, err := LoadFromCSV(ctx, bytes.NewReader(buf.Bytes()), CSVLoadOptions{LargeDataSet: true})
+		if err != nil {
+			t.Fatalf("%s: csv import error: %v", c, err)
+		}
+
+		if eq, err := df.IsEqual(ctx, df2, dataframe.IsEqualOptions{CheckName: true}); !eq {
+			t.Errorf("%s: csv not equal: %v\n%v", c, err, df2.Table())
+		}
+
+		// JSON
+		buf.Reset()
+		if err := exports.ExportToJSON(ctx, &buf, df, exports.JSONExportOptions{Compression: c}); err != nil {
+			t.Fatalf("%s: json export error: %v", c, err)
+		}
+
+		df2, err = LoadFromJSON(ctx, bytes.NewReader(buf.Bytes()), JSONLoadOptions{LargeDataSet: true})
+		if err != nil {
+			t.Fatalf("%s: json import error: %v", c, err)
+		}
+
+		if eq, err := df.IsEqual(ctx, df2, dataframe.IsEqualOptions{CheckName: true}); !eq {
+			t.Errorf("%s: json not equal: %v\n%v", c, err, df2.Table())
+		}
+	}
+
+	// bzip2 (there is no bzip2 compressor in the standard library)
+	bz, _ := base64.StdEncoding.DecodeString("QlpoOTFBWSZTWbzHKEUAAARZgAAQAAQwADAAAGAgADEMCCNBmo4EIheLuSKcKEheY5QigA==")
+
+	df2, err := LoadFromCSV(ctx, bytes.NewReader(bz))
+	if err != nil {
+		t.Fatalf("bzip2: csv import error: %v", err)
+	}
+
+	if eq, err := df.IsEqual(ctx, df2, dataframe.IsEqualOptions{CheckName: true}); !eq {
+		t.Errorf("bzip2: csv not equal: %v\n%v", err, df2.Table())
+	}
+}
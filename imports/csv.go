@@ -45,6 +45,7 @@ type CSVLoadOptions struct {
 	// The key must be the case-sensitive field name.
 	// The value for a given key must be of the data type of the data.
 	// eg. For a string use "". For a int64 use int64(0). What is relevant is the data type and not the value itself.
+	// For a time.Duration use time.Duration(0). The values are parsed using time.ParseDuration.
 	//
 	// NOTE: A custom Series must implement NewSerieser interface and be able to interpret strings to work.
 	DictateDataType map[string]interface{}
@@ -63,8 +64,15 @@ type CSVLoadOptions struct {
 }
 
 // LoadFromCSV will load data from a csv file.
+// gzip, bzip2, zstd and xz compressed data is automatically detected and decompressed.
 func LoadFromCSV(ctx context.Context, r io.ReadSeeker, options ...CSVLoadOptions) (*dataframe.DataFrame, error) {
 
+	// Transparently decompress gzip, bzip2, zstd and xz data
+	r, err := decompress(r)
+	if err != nil {
+		return nil, err
+	}
+
 	var init *dataframe.SeriesInit
 
 	cr := csv.NewReader(r)
@@ -141,6 +149,8 @@ func LoadFromCSV(ctx context.Context, r io.ReadSeeker, options ...CSVLoadOptions
 						seriess = append(seriess, dataframe.NewSeriesString(name, init))
 					case time.Time:
 						seriess = append(seriess, dataframe.NewSeriesTime(name, init))
+					case time.Duration:
+						seriess = append(seriess, dataframe.NewSeriesDuration(name, init))
 					case dataframe.NewSerieser:
 						seriess = append(seriess, T.NewSeries(name, init))
 					case Converter:
@@ -233,6 +243,12 @@ func LoadFromCSV(ctx context.Context, r io.ReadSeeker, options ...CSVLoadOptions
 						} else {
 							insertVals = append(insertVals, t)
 						}
+					case time.Duration:
+						d, err := time.ParseDuration(v)
+						if err != nil {
+							return nil, fmt.Errorf("can't force string: %s to time.Duration. row: %d field: %s", v, row-1, name)
+						}
+						insertVals = append(insertVals, d)
 					case dataframe.NewSerieser:
 						insertVals = append(insertVals, v)
 					case Converter:
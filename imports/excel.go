@@ -0,0 +1,253 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package imports
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	dataframe "github.com/rocketlaunchr/dataframe-go"
+	"github.com/tealeg/xlsx/v3"
+)
+
+// ExcelLoadOptions is likely to change.
+type ExcelLoadOptions struct {
+
+	// Sheet is used to select the worksheet to import.
+	// It can be the name of the sheet (string) or the 0-based index of the sheet (int).
+	// When not set, the first sheet is used.
+	Sheet interface{}
+
+	// Range is used to import a subset of cells from the sheet.
+	// It must be in A1 notation (eg. "B2:F100").
+	// When not set, all the cells in use by the sheet are imported.
+	Range string
+
+	// HeaderRow is the row (relative to the start of Range) which contains the field names.
+	// Rows above the HeaderRow are ignored.
+	// A blank heading is replaced by the column's letter(s) (eg. "C").
+	HeaderRow int
+
+	// SkipRows is the number of rows directly below the HeaderRow that should be ignored.
+	SkipRows int
+
+	// DontDetectDates disables the automatic conversion of date cells to a SeriesTime.
+	// By default, a column is imported as a SeriesTime if all its non-nil cells
+	// are formatted as dates in the spreadsheet. DictateDataType always takes precedence.
+	DontDetectDates bool
+
+	// DictateDataType is used to inform LoadFromExcel what the true underlying data type is for a given field name.
+	// The key must be the case-sensitive field name.
+	// The value for a given key must be of the data type of the data.
+	// eg. For a string use "". For a int64 use int64(0). What is relevant is the data type and not the value itself.
+	//
+	// NOTE: A custom Series must implement NewSerieser interface and be able to interpret strings to work.
+	DictateDataType map[string]interface{}
+
+	// NilValue allows you to set what string value in the spreadsheet should be interpreted as a nil value for
+	// the purposes of insertion. Empty cells are always interpreted as nil.
+	//
+	// Common values are: NULL, \N, NaN, NA
+	NilValue *string
+
+	// InferDataTypes can be set to true if the underlying data type should be automatically detected.
+	// Using DictateDataType is the recommended approach (especially for large datasets or memory constrained systems).
+	// DictateDataType always takes precedence when determining the type.
+	// If the data type could not be detected, NewSeriesString is used.
+	InferDataTypes bool
+}
+
+// LoadFromExcel will load data from a sheet of an excel (xlsx) file.
+func LoadFromExcel(ctx context.Context, r io.ReadSeeker, options ...ExcelLoadOptions) (*dataframe.DataFrame, error) {
+
+	var opts ExcelLoadOptions
+	if len(options) > 0 {
+		opts = options[0]
+	}
+
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := xlsx.OpenBinary(b)
+	if err != nil {
+		return nil, err
+	}
+
+	sheet, err := excelSheet(file, opts.Sheet)
+	if err != nil {
+		return nil, err
+	}
+
+	// Determine the bounds of the cells to import
+	minRow, minCol, maxRow, maxCol := 0, 0, sheet.MaxRow-1, sheet.MaxCol-1
+	if opts.Range != "" {
+		minRow, minCol, maxRow, maxCol, err = excelRange(opts.Range)
+		if err != nil {
+			return nil, err
+		}
+		if maxRow > sheet.MaxRow-1 {
+			maxRow = sheet.MaxRow - 1
+		}
+		if maxCol > sheet.MaxCol-1 {
+			maxCol = sheet.MaxCol - 1
+		}
+	}
+
+	headerRow := minRow + opts.HeaderRow
+	if headerRow > maxRow || minCol > maxCol {
+		return nil, dataframe.ErrNoRows
+	}
+
+	// Field names
+	names := []string{}
+	hRow, err := sheet.Row(headerRow)
+	if err != nil {
+		return nil, err
+	}
+	for col := minCol; col <= maxCol; col++ {
+		name := strings.TrimSpace(hRow.GetCell(col).Value)
+		if name == "" {
+			name = xlsx.ColIndexToLetters(col)
+		}
+		names = append(names, name)
+	}
+
+	// Read cells. A value is either nil, a string or a time.Time.
+	startRow := headerRow + 1 + opts.SkipRows
+	nRows := maxRow - startRow + 1
+	if nRows < 0 {
+		nRows = 0
+	}
+
+	vals := make([][]interface{}, len(names))
+	for i := range vals {
+		vals[i] = make([]interface{}, 0, nRows)
+	}
+	allDates := make([]bool, len(names))
+	nonNil := make([]int, len(names))
+	for i := range allDates {
+		allDates[i] = true
+	}
+
+	for row := startRow; row <= maxRow; row++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		sRow, err := sheet.Row(row)
+		if err != nil {
+			return nil, err
+		}
+
+		for col := minCol; col <= maxCol; col++ {
+			idx := col - minCol
+			cell := sRow.GetCell(col)
+
+			if cell.Value == "" || (opts.NilValue != nil && cell.Value == *opts.NilValue) {
+				vals[idx] = append(vals[idx], nil)
+				continue
+			}
+			nonNil[idx]++
+
+			if cell.Type() == xlsx.CellTypeNumeric && cell.IsTime() {
+				t, err := cell.GetTime(file.Date1904)
+				if err == nil {
+					vals[idx] = append(vals[idx], t)
+					continue
+				}
+			}
+
+			allDates[idx] = false
+			vals[idx] = append(vals[idx], cell.Value)
+		}
+	}
+
+	// Create the series
+	init := &dataframe.SeriesInit{Capacity: nRows}
+	seriess := []dataframe.Series{}
+
+	for idx, name := range names {
+		var s dataframe.Series
+
+		if typ, exists := opts.DictateDataType[name]; exists {
+			s, err = dictatedSeries(name, typ, vals[idx], init)
+			if err != nil {
+				return nil, err
+			}
+		} else if !opts.DontDetectDates && allDates[idx] && nonNil[idx] > 0 {
+			s = dataframe.NewSeriesTime(name, init, vals[idx]...)
+		} else if opts.InferDataTypes {
+			is := newInferSeries(name, &nRows)
+			for _, v := range vals[idx] {
+				is.Insert(0, timeString(v))
+			}
+			s, _ = is.inferred()
+		} else {
+			ss := dataframe.NewSeriesString(name, init)
+			for _, v := range vals[idx] {
+				ss.Append(timeString(v), dataframe.DontLock)
+			}
+			s = ss
+		}
+
+		seriess = append(seriess, s)
+	}
+
+	return dataframe.NewDataFrame(seriess...), nil
+}
+
+func excelSheet(file *xlsx.File, sheet interface{}) (*xlsx.Sheet, error) {
+
+	if len(file.Sheets) == 0 {
+		return nil, dataframe.ErrNoRows
+	}
+
+	switch s := sheet.(type) {
+	case nil:
+		return file.Sheets[0], nil
+	case int:
+		if s < 0 || s >= len(file.Sheets) {
+			return nil, fmt.Errorf("sheet index out of range: %d", s)
+		}
+		return file.Sheets[s], nil
+	case string:
+		sh, exists := file.Sheet[s]
+		if !exists {
+			return nil, fmt.Errorf("sheet not found: %s", s)
+		}
+		return sh, nil
+	default:
+		return nil, errors.New("Sheet must be a string or int")
+	}
+}
+
+// excelRange returns the 0-based bounds of a range in A1 notation (eg. "B2:F100").
+func excelRange(r string) (minRow, minCol, maxRow, maxCol int, _ error) {
+
+	parts := strings.Split(strings.ToUpper(strings.TrimSpace(r)), ":")
+	if len(parts) != 2 {
+		return 0, 0, 0, 0, fmt.Errorf("invalid range: %s", r)
+	}
+
+	minCol, minRow, err := xlsx.GetCoordsFromCellIDString(parts[0])
+	if err != nil {
+		return 0, 0, 0, 0, fmt.Errorf("invalid range: %s", r)
+	}
+
+	maxCol, maxRow, err = xlsx.GetCoordsFromCellIDString(parts[1])
+	if err != nil {
+		return 0, 0, 0, 0, fmt.Errorf("invalid range: %s", r)
+	}
+
+	if minRow < 0 || minCol < 0 || minRow > maxRow || minCol > maxCol {
+		return 0, 0, 0, 0, fmt.Errorf("invalid range: %s", r)
+	}
+
+	return minRow, minCol, maxRow, maxCol, nil
+}
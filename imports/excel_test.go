@@ -0,0 +1,73 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package imports
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	dataframe "github.com/rocketlaunchr/dataframe-go"
+	"github.com/tealeg/xlsx/v3"
+)
+
+func TestExcelImport(t *testing.T) {
+
+	file := xlsx.NewFile()
+	file.AddSheet("ignore")
+	sheet, _ := file.AddSheet("data")
+
+	date := time.Date(2012, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	rows := [][]interface{}{
+		{"Report title"},
+		{"", "Country", "Date", "Age", "Amount"},
+		{"", "units", "", "years", "$"},
+		{"", "United States", date, 50, 112.1},
+		{"", "United Kingdom", date.AddDate(0, 0, 1), "NA", 18.2},
+		{"", "Spain", nil, 66, 555.42},
+	}
+
+	for _, r := range rows {
+		row := sheet.AddRow()
+		for _, v := range r {
+			cell := row.AddCell()
+			switch v := v.(type) {
+			case time.Time:
+				cell.SetDate(v)
+			case nil:
+			default:
+				cell.SetValue(v)
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := file.Write(&buf); err != nil {
+		t.Fatalf("excel write error: %v", err)
+	}
+
+	opts := ExcelLoadOptions{
+		Sheet:          "data",
+		Range:          "B2:E6",
+		SkipRows:       1,
+		InferDataTypes: true,
+		NilValue:       &[]string{"NA"}[0],
+	}
+
+	df, err := LoadFromExcel(ctx, bytes.NewReader(buf.Bytes()), opts)
+	if err != nil {
+		t.Fatalf("excel import error: %v", err)
+	}
+
+	expDf := dataframe.NewDataFrame(
+		dataframe.NewSeriesString("Country", nil, "United States", "United Kingdom", "Spain"),
+		dataframe.NewSeriesTime("Date", nil, date, date.AddDate(0, 0, 1), nil),
+		dataframe.NewSeriesInt64("Age", nil, 50, nil, 66),
+		dataframe.NewSeriesFloat64("Amount", nil, 112.1, 18.2, 555.42),
+	)
+
+	if eq, err := df.IsEqual(ctx, expDf, dataframe.IsEqualOptions{CheckName: true}); !eq {
+		t.Errorf("excel import not equal: %v\n%v", err, df.Table())
+	}
+}
@@ -0,0 +1,269 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package imports
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+
+	dataframe "github.com/rocketlaunchr/dataframe-go"
+)
+
+// FixedWidthColumn describes the location of a field within each line of a fixed-width file.
+type FixedWidthColumn struct {
+
+	// Name is the name of the field.
+	// If not set, the name is obtained from the header line (when HasHeader is set) or
+	// else it is set to "Column N" (where N is the 1-based position of the column).
+	Name string
+
+	// Start is the 0-based position of the first character of the field.
+	Start int
+
+	// Width is the number of characters of the field.
+	// A Width of 0 means the field extends to the end of the line.
+	Width int
+
+	// Type can be used to dictate the true underlying data type of the field.
+	// It accepts the same values as DictateDataType, which takes precedence.
+	Type interface{}
+}
+
+// FixedWidthLoadOptions is likely to change.
+type FixedWidthLoadOptions struct {
+
+	// Columns describes the location of each field.
+	// If not set, the boundaries of the fields are inferred from positions which contain
+	// whitespace in every line (including the header line).
+	Columns []FixedWidthColumn
+
+	// HasHeader should be set if the first line (after SkipRows) contains the field names.
+	HasHeader bool
+
+	// SkipRows is the number of lines at the start of the file that should be ignored.
+	SkipRows int
+
+	// DontTrim disables the removal of leading and trailing white space from each field.
+	DontTrim bool
+
+	// DictateDataType is used to inform LoadFromFixedWidth what the true underlying data type is for a given field name.
+	// The key must be the case-sensitive field name.
+	// The value for a given key must be of the data type of the data.
+	// eg. For a string use "". For a int64 use int64(0). What is relevant is the data type and not the value itself.
+	//
+	// NOTE: A custom Series must implement NewSerieser interface and be able to interpret strings to work.
+	DictateDataType map[string]interface{}
+
+	// NilValue allows you to set what string value in the file should be interpreted as a nil value for
+	// the purposes of insertion. Blank fields are always interpreted as nil.
+	//
+	// Common values are: NULL, \N, NaN, NA
+	NilValue *string
+
+	// InferDataTypes can be set to true if the underlying data type should be automatically detected.
+	// Using DictateDataType is the recommended approach (especially for large datasets or memory constrained systems).
+	// DictateDataType always takes precedence when determining the type.
+	// If the data type could not be detected, NewSeriesString is used.
+	InferDataTypes bool
+}
+
+// LoadFromFixedWidth will load data from a fixed-width text file.
+// Positions and widths are measured in characters (runes) and not bytes. Blank lines are ignored.
+//
+// Example:
+//
+//  opts := imports.FixedWidthLoadOptions{
+//     Columns: []imports.FixedWidthColumn{
+//        {Name: "id", Start: 0, Width: 6, Type: int64(0)},
+//        {Name: "name", Start: 6, Width: 20},
+//        {Name: "balance", Start: 26, Width: 12, Type: float64(0)},
+//     },
+//  }
+//
+func LoadFromFixedWidth(ctx context.Context, r io.Reader, options ...FixedWidthLoadOptions) (*dataframe.DataFrame, error) {
+
+	var opts FixedWidthLoadOptions
+	if len(options) > 0 {
+		opts = options[0]
+	}
+
+	// Read lines
+	lines := [][]rune{}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 16*1024*1024)
+	for skipped := 0; scanner.Scan(); {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if skipped < opts.SkipRows {
+			skipped++
+			continue
+		}
+
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		lines = append(lines, []rune(line))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	cols := opts.Columns
+	if len(cols) == 0 {
+		cols = fixedWidthInfer(lines)
+	}
+	if len(cols) == 0 {
+		return nil, dataframe.ErrNoRows
+	}
+
+	for _, c := range cols {
+		if c.Start < 0 || c.Width < 0 {
+			return nil, errors.New("fixed width column has negative Start or Width")
+		}
+	}
+
+	// Field names
+	names := make([]string, len(cols))
+	for i, c := range cols {
+		name := c.Name
+		if name == "" && opts.HasHeader && len(lines) > 0 {
+			name = strings.TrimSpace(fixedWidthField(lines[0], c))
+		}
+		if name == "" {
+			name = fmt.Sprintf("Column %d", i+1)
+		}
+		names[i] = name
+	}
+
+	if opts.HasHeader {
+		if len(lines) == 0 {
+			return nil, dataframe.ErrNoRows
+		}
+		lines = lines[1:]
+	}
+
+	// Extract fields. A value is either nil or a string.
+	nRows := len(lines)
+
+	vals := make([][]interface{}, len(cols))
+	for i := range vals {
+		vals[i] = make([]interface{}, 0, nRows)
+	}
+
+	for _, line := range lines {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		for idx, c := range cols {
+			v := fixedWidthField(line, c)
+			if !opts.DontTrim {
+				v = strings.TrimSpace(v)
+			}
+
+			if strings.TrimSpace(v) == "" || (opts.NilValue != nil && v == *opts.NilValue) {
+				vals[idx] = append(vals[idx], nil)
+				continue
+			}
+			vals[idx] = append(vals[idx], v)
+		}
+	}
+
+	// Create the series
+	init := &dataframe.SeriesInit{Capacity: nRows}
+	seriess := []dataframe.Series{}
+
+	for idx, name := range names {
+		var s dataframe.Series
+
+		typ, exists := opts.DictateDataType[name]
+		if !exists && cols[idx].Type != nil {
+			typ, exists = cols[idx].Type, true
+		}
+
+		if exists {
+			var err error
+			s, err = dictatedSeries(name, typ, vals[idx], init)
+			if err != nil {
+				return nil, err
+			}
+		} else if opts.InferDataTypes {
+			is := newInferSeries(name, &nRows)
+			for _, v := range vals[idx] {
+				is.Insert(0, v)
+			}
+			s, _ = is.inferred()
+		} else {
+			s = dataframe.NewSeriesString(name, init, vals[idx]...)
+		}
+
+		seriess = append(seriess, s)
+	}
+
+	return dataframe.NewDataFrame(seriess...), nil
+}
+
+// fixedWidthField extracts a field from a line.
+func fixedWidthField(line []rune, c FixedWidthColumn) string {
+
+	if c.Start >= len(line) {
+		return ""
+	}
+
+	end := len(line)
+	if c.Width > 0 && c.Start+c.Width < end {
+		end = c.Start + c.Width
+	}
+
+	return string(line[c.Start:end])
+}
+
+// fixedWidthInfer determines the boundaries of the fields from positions
+// which contain whitespace in every line.
+func fixedWidthInfer(lines [][]rune) []FixedWidthColumn {
+
+	maxLen := 0
+	for _, line := range lines {
+		if len(line) > maxLen {
+			maxLen = len(line)
+		}
+	}
+
+	used := make([]bool, maxLen)
+	for _, line := range lines {
+		for i, c := range line {
+			if !unicode.IsSpace(c) {
+				used[i] = true
+			}
+		}
+	}
+
+	cols := []FixedWidthColumn{}
+	for i := 0; i < maxLen; i++ {
+		if !used[i] {
+			continue
+		}
+
+		start := i
+		for i < maxLen && used[i] {
+			i++
+		}
+		cols = append(cols, FixedWidthColumn{Start: start, Width: i - start})
+	}
+
+	// The last field extends to the end of the line
+	if len(cols) > 0 {
+		cols[len(cols)-1].Width = 0
+	}
+
+	return cols
+}
@@ -0,0 +1,72 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package imports
+
+import (
+	"strings"
+	"testing"
+
+	dataframe "github.com/rocketlaunchr/dataframe-go"
+)
+
+func TestFixedWidthImport(t *testing.T) {
+
+	data := `Accounts extract
+ID    NAME          BALANCE  AGE
+000001United States  112.10   50
+000002Spain          NA       66
+000003              -18.20     
+`
+
+	expDf := dataframe.NewDataFrame(
+		dataframe.NewSeriesInt64("ID", nil, 1, 2, 3),
+		dataframe.NewSeriesString("NAME", nil, "United States", "Spain", nil),
+		dataframe.NewSeriesFloat64("BALANCE", nil, 112.1, nil, -18.2),
+		dataframe.NewSeriesInt64("AGE", nil, 50, 66, nil),
+	)
+
+	// Column specs
+	opts := FixedWidthLoadOptions{
+		Columns: []FixedWidthColumn{
+			{Start: 0, Width: 6, Type: int64(0)},
+			{Start: 6, Width: 14},
+			{Start: 20, Width: 9},
+			{Start: 29},
+		},
+		HasHeader:       true,
+		SkipRows:        1,
+		NilValue:        &[]string{"NA"}[0],
+		DictateDataType: map[string]interface{}{"BALANCE": float64(0)},
+		InferDataTypes:  true,
+	}
+
+	df, err := LoadFromFixedWidth(ctx, strings.NewReader(data), opts)
+	if err != nil {
+		t.Fatalf("fixed width import error: %v", err)
+	}
+
+	if eq, err := df.IsEqual(ctx, expDf, dataframe.IsEqualOptions{CheckName: true}); !eq {
+		t.Errorf("fixed width import not equal: %v\n%v", err, df.Table())
+	}
+
+	// Inferred boundaries
+	data = `a   bb  c
+1   2.5 x
+22  3.0 yy
+`
+
+	expDf = dataframe.NewDataFrame(
+		dataframe.NewSeriesInt64("a", nil, 1, 22),
+		dataframe.NewSeriesFloat64("bb", nil, 2.5, 3.0),
+		dataframe.NewSeriesString("c", nil, "x", "yy"),
+	)
+
+	df, err = LoadFromFixedWidth(ctx, strings.NewReader(data), FixedWidthLoadOptions{HasHeader: true, InferDataTypes: true})
+	if err != nil {
+		t.Fatalf("fixed width import error: %v", err)
+	}
+
+	if eq, err := df.IsEqual(ctx, expDf, dataframe.IsEqualOptions{CheckName: true}); !eq {
+		t.Errorf("fixed width import not equal: %v\n%v", err, df.Table())
+	}
+}
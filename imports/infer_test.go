@@ -59,3 +59,35 @@ Spain,2012-02-01,66,555.42,00241
 		t.Errorf("csv import not equal")
 	}
 }
+
+func TestCSVImportDuration(t *testing.T) {
+
+	csvStr := `name,elapsed
+a,1h2m
+b,NA
+c,350ms
+`
+
+	opts := CSVLoadOptions{
+		NilValue: &[]string{"NA"}[0],
+		DictateDataType: map[string]interface{}{
+			"elapsed": time.Duration(0),
+		},
+	}
+
+	df, err := LoadFromCSV(ctx, strings.NewReader(csvStr), opts)
+	if err != nil {
+		t.Fatalf("csv import error: %v", err)
+	}
+
+	expected := dataframe.NewSeriesDuration("elapsed", nil, time.Hour+2*time.Minute, nil, 350*time.Millisecond)
+	if eq, _ := df.Series[1].IsEqual(ctx, expected); !eq {
+		t.Errorf("wrong val: expected: %v actual: %v", expected, df.Series[1])
+	}
+
+	// Invalid durations
+	_, err = LoadFromCSV(ctx, strings.NewReader("elapsed\n5 minutes\n"), opts)
+	if err == nil {
+		t.Errorf("expected error for invalid duration")
+	}
+}
@@ -0,0 +1,174 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package imports
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// JSONNormalize is used to normalize semi-structured JSON data into a flat table.
+// It is similar to pandas' json_normalize function.
+//
+// See: https://pandas.pydata.org/pandas-docs/stable/reference/api/pandas.json_normalize.html
+//
+// Example:
+//
+//  // {"state": "Florida", "info": {"governor": "Rick Scott"}, "counties": [{"name": "Dade", "population": 12345}, ...]}
+//
+//  opts := imports.JSONLoadOptions{
+//     Normalize: &imports.JSONNormalize{
+//        RecordPath: []string{"counties"},
+//        Meta:       [][]string{{"state"}, {"info", "governor"}},
+//     },
+//  }
+//
+// The resulting fields are: name, population, state and info.governor.
+type JSONNormalize struct {
+
+	// RecordPath is the path to a list of records within each JSON document.
+	// Each record becomes a row. If an intermediate element of the path is a list,
+	// the records of each of its elements are concatenated.
+	// When not set, each JSON document is a row.
+	RecordPath []string
+
+	// Meta is a list of paths to fields that are carried down to each row.
+	// A path is resolved from the JSON document unless it begins with a (strict) prefix of RecordPath.
+	// In that case, it is resolved from the intermediate record at that level.
+	// A field that is not found is stored as nil.
+	Meta [][]string
+
+	// RecordPrefix is prepended to the field names of records.
+	RecordPrefix string
+
+	// MetaPrefix is prepended to the field names of Meta fields.
+	MetaPrefix string
+
+	// Separator is used to join the keys of nested objects. The default is ".".
+	Separator string
+
+	// MaxDepth is the maximum number of levels of nested objects that are flattened (like pandas' max_level).
+	// eg. With a MaxDepth of 1, {"b": {"c": {"d": 2}}} produces the field b.c containing {"d":2}.
+	// Objects beyond MaxDepth are stored as JSON strings.
+	// When not set, objects of all depths are flattened.
+	MaxDepth int
+}
+
+func (n *JSONNormalize) separator() string {
+	if n.Separator == "" {
+		return "."
+	}
+	return n.Separator
+}
+
+// rows converts a JSON document into a list of flattened rows.
+func (n *JSONNormalize) rows(doc map[string]interface{}) ([]map[string]interface{}, error) {
+
+	if len(n.RecordPath) == 0 {
+		return []map[string]interface{}{n.flatten(doc, n.RecordPrefix, 1)}, nil
+	}
+
+	out := []map[string]interface{}{}
+	err := n.walk(doc, []map[string]interface{}{doc}, &out)
+	return out, err
+}
+
+// walk follows the RecordPath. parents contains the document and the intermediate records encountered.
+func (n *JSONNormalize) walk(obj map[string]interface{}, parents []map[string]interface{}, out *[]map[string]interface{}) error {
+
+	level := len(parents) - 1
+
+	var elems []interface{}
+	switch v := obj[n.RecordPath[level]].(type) {
+	case []interface{}:
+		elems = v
+	case map[string]interface{}:
+		elems = []interface{}{v}
+	case nil:
+		return nil
+	default:
+		return fmt.Errorf("record path element is not a list or object: %s", n.RecordPath[level])
+	}
+
+	for _, e := range elems {
+		record, ok := e.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("record is not an object: %v", e)
+		}
+
+		if level < len(n.RecordPath)-1 {
+			if err := n.walk(record, append(parents[:len(parents):len(parents)], record), out); err != nil {
+				return err
+			}
+			continue
+		}
+
+		row := n.flatten(record, n.RecordPrefix, 1)
+		for _, path := range n.Meta {
+			name := n.MetaPrefix + strings.Join(path, n.separator())
+			if _, exists := row[name]; exists {
+				return fmt.Errorf("conflicting metadata name: %s", name)
+			}
+			row[name] = n.meta(path, parents)
+		}
+		*out = append(*out, row)
+	}
+
+	return nil
+}
+
+// meta resolves the value of a Meta path.
+func (n *JSONNormalize) meta(path []string, parents []map[string]interface{}) interface{} {
+
+	// Determine the level from which the path is resolved
+	level := 0
+	for level < len(path)-1 && level < len(parents)-1 && path[level] == n.RecordPath[level] {
+		level++
+	}
+
+	var v interface{} = parents[level]
+	for _, key := range path[level:] {
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		v = obj[key]
+	}
+
+	return jsonScalar(v)
+}
+
+// flatten is similar to parseObject but supports a custom separator and maximum depth.
+func (n *JSONNormalize) flatten(v map[string]interface{}, prefix string, depth int) map[string]interface{} {
+
+	out := map[string]interface{}{}
+
+	for k, t := range v {
+		key := prefix + k
+
+		switch v := t.(type) {
+		case map[string]interface{}:
+			if n.MaxDepth == 0 || depth <= n.MaxDepth {
+				for k, t := range n.flatten(v, key+n.separator(), depth+1) {
+					out[k] = t
+				}
+				continue
+			}
+		}
+		out[key] = jsonScalar(t)
+	}
+
+	return out
+}
+
+// jsonScalar converts objects and lists to a JSON string.
+func jsonScalar(v interface{}) interface{} {
+	switch v.(type) {
+	case map[string]interface{}, []interface{}:
+		b, _ := json.Marshal(v)
+		return string(b)
+	default:
+		return v
+	}
+}
@@ -26,23 +26,50 @@ type JSONLoadOptions struct {
 	// The key must be the case-sensitive field name.
 	// The value for a given key must be of the data type of the data.
 	// eg. For a string use "". For a int64 use int64(0). What is relevant is the data type and not the value itself.
+	// For a time.Duration use time.Duration(0). Strings are parsed using time.ParseDuration and numbers are interpreted as nanoseconds.
 	//
 	// NOTE: A custom Series must implement NewSerieser interface and be able to interpret strings to work.
 	DictateDataType map[string]interface{}
 
 	// ErrorOnUnknownFields will generate an error if an unknown field is encountered after the first row.
 	ErrorOnUnknownFields bool
+
+	// Normalize can be set to flatten nested objects and explode lists of records into multiple rows.
+	// LargeDataSet is ignored when Normalize is set.
+	Normalize *JSONNormalize
 }
 
-// LoadFromJSON will load data from a jsonl file.
+// LoadFromJSON will load data from a jsonl file or a JSON document containing an array of objects.
 // The first row determines which fields will be imported for subsequent rows.
+// gzip, bzip2, zstd and xz compressed data is automatically detected and decompressed.
 func LoadFromJSON(ctx context.Context, r io.ReadSeeker, options ...JSONLoadOptions) (*dataframe.DataFrame, error) {
 
+	// Transparently decompress gzip, bzip2, zstd and xz data
+	r, err := decompress(r)
+	if err != nil {
+		return nil, err
+	}
+
 	var init *dataframe.SeriesInit
 
+	var normalize *JSONNormalize
 	if len(options) > 0 {
+		normalize = options[0].Normalize
+	}
+
+	isArray, err := jsonIsArray(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(options) > 0 && normalize == nil {
 		// Count how many rows we have in order to preallocate underlying slices
 		if options[0].LargeDataSet {
+			start, err := r.Seek(0, io.SeekCurrent)
+			if err != nil {
+				return nil, err
+			}
+
 			init = &dataframe.SeriesInit{}
 			dec := json.NewDecoder(r)
 
@@ -55,7 +82,7 @@ func LoadFromJSON(ctx context.Context, r io.ReadSeeker, options ...JSONLoadOptio
 				t, err := dec.Token()
 				if err != nil {
 					if err == io.EOF {
-						r.Seek(0, io.SeekStart)
+						r.Seek(start, io.SeekStart)
 						break
 					}
 					return nil, err
@@ -83,22 +110,51 @@ func LoadFromJSON(ctx context.Context, r io.ReadSeeker, options ...JSONLoadOptio
 
 	dec := json.NewDecoder(r)
 	dec.UseNumber()
+
+	if isArray {
+		// Consume opening bracket of array
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+	}
+
+	pending := []map[string]interface{}{} // rows generated from the current JSON document
+
 	for {
 		if err := ctx.Err(); err != nil {
 			return nil, err
 		}
 
-		var raw map[string]interface{}
-		err := dec.Decode(&raw)
-		if err != nil {
-			if err == io.EOF {
+		if len(pending) == 0 {
+			if isArray && !dec.More() {
 				break
 			}
-			return nil, err
+
+			var raw map[string]interface{}
+			err := dec.Decode(&raw)
+			if err != nil {
+				if err == io.EOF {
+					break
+				}
+				return nil, err
+			}
+
+			if normalize != nil {
+				pending, err = normalize.rows(raw)
+				if err != nil {
+					return nil, err
+				}
+				if len(pending) == 0 {
+					continue
+				}
+			} else {
+				pending = append(pending, parseObject(raw, ""))
+			}
 		}
-		row++
 
-		vals := parseObject(raw, "")
+		vals := pending[0]
+		pending = pending[1:]
+		row++
 
 		if row == 1 {
 
@@ -128,6 +184,8 @@ func LoadFromJSON(ctx context.Context, r io.ReadSeeker, options ...JSONLoadOptio
 						seriess = append(seriess, dataframe.NewSeriesString(name, init))
 					case time.Time:
 						seriess = append(seriess, dataframe.NewSeriesTime(name, init))
+					case time.Duration:
+						seriess = append(seriess, dataframe.NewSeriesDuration(name, init))
 					case dataframe.NewSerieser:
 						seriess = append(seriess, T.NewSeries(name, init))
 					case Converter:
@@ -281,3 +339,34 @@ func LoadFromJSON(ctx context.Context, r io.ReadSeeker, options ...JSONLoadOptio
 
 	return df, nil
 }
+
+// jsonIsArray reports whether the JSON data begins with an array.
+// The read position of r is not changed.
+func jsonIsArray(r io.ReadSeeker) (bool, error) {
+
+	start, err := r.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return false, err
+	}
+	defer r.Seek(start, io.SeekStart)
+
+	b := make([]byte, 1)
+	for {
+		_, err := r.Read(b)
+		if err != nil {
+			if err == io.EOF {
+				return false, nil
+			}
+			return false, err
+		}
+
+		switch b[0] {
+		case ' ', '\t', '\r', '\n':
+			continue
+		case '[':
+			return true, nil
+		default:
+			return false, nil
+		}
+	}
+}
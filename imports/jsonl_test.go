@@ -0,0 +1,103 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package imports
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	dataframe "github.com/rocketlaunchr/dataframe-go"
+)
+
+func TestJSONNormalize(t *testing.T) {
+
+	data := `[
+	{"state": "Florida", "info": {"governor": "Rick Scott", "shortname": "FL"},
+	 "counties": [{"name": "Dade", "population": 12345, "geo": {"lat": 25.7}},
+	              {"name": "Broward", "population": 40000, "geo": {"lat": 26.1}}]},
+	{"state": "Ohio", "info": {"governor": "John Kasich", "shortname": "OH"},
+	 "counties": [{"name": "Summit", "population": 1234, "geo": {"lat": 41.1}}]}
+]`
+
+	opts := JSONLoadOptions{
+		DictateDataType: map[string]interface{}{
+			"population": int64(0),
+			"geo_lat":    float64(0),
+		},
+		Normalize: &JSONNormalize{
+			RecordPath: []string{"counties"},
+			Meta:       [][]string{{"state"}, {"info", "governor"}},
+			MetaPrefix: "meta_",
+			Separator:  "_",
+		},
+	}
+
+	df, err := LoadFromJSON(ctx, strings.NewReader(data), opts)
+	if err != nil {
+		t.Fatalf("json import error: %v", err)
+	}
+
+	expDf := dataframe.NewDataFrame(
+		dataframe.NewSeriesFloat64("geo_lat", nil, 25.7, 26.1, 41.1),
+		dataframe.NewSeriesString("meta_info_governor", nil, "Rick Scott", "Rick Scott", "John Kasich"),
+		dataframe.NewSeriesString("meta_state", nil, "Florida", "Florida", "Ohio"),
+		dataframe.NewSeriesString("name", nil, "Dade", "Broward", "Summit"),
+		dataframe.NewSeriesInt64("population", nil, 12345, 40000, 1234),
+	)
+
+	if eq, err := df.IsEqual(ctx, expDf, dataframe.IsEqualOptions{CheckName: true}); !eq {
+		t.Errorf("json import not equal: %v\n%v", err, df.Table())
+	}
+
+	// MaxDepth
+	opts = JSONLoadOptions{Normalize: &JSONNormalize{MaxDepth: 1}}
+
+	df, err = LoadFromJSON(ctx, strings.NewReader(`{"a": 1, "b": {"c": {"d": 2}}}`+"\n"+`{"a": 3, "b": {"c": null}}`), opts)
+	if err != nil {
+		t.Fatalf("json import error: %v", err)
+	}
+
+	expDf = dataframe.NewDataFrame(
+		dataframe.NewSeriesString("a", nil, "1", "3"),
+		dataframe.NewSeriesString("b.c", nil, `{"d":2}`, nil),
+	)
+
+	if eq, err := df.IsEqual(ctx, expDf, dataframe.IsEqualOptions{CheckName: true}); !eq {
+		t.Errorf("json import not equal: %v\n%v", err, df.Table())
+	}
+
+	opts = JSONLoadOptions{Normalize: &JSONNormalize{MaxDepth: 2}}
+
+	df, err = LoadFromJSON(ctx, strings.NewReader(`{"a": 1, "b": {"c": {"d": 2}}}`), opts)
+	if err != nil {
+		t.Fatalf("json import error: %v", err)
+	}
+
+	if names := df.Names(); len(names) != 2 || names[1] != "b.c.d" {
+		t.Errorf("wrong names: expected: %v actual: %v", []string{"a", "b.c.d"}, names)
+	}
+}
+
+func TestJSONImportDuration(t *testing.T) {
+
+	data := `{"elapsed": "1h2m"}
+{"elapsed": null}
+{"elapsed": 1000}`
+
+	opts := JSONLoadOptions{
+		DictateDataType: map[string]interface{}{
+			"elapsed": time.Duration(0),
+		},
+	}
+
+	df, err := LoadFromJSON(ctx, strings.NewReader(data), opts)
+	if err != nil {
+		t.Fatalf("json import error: %v", err)
+	}
+
+	expected := dataframe.NewSeriesDuration("elapsed", nil, time.Hour+2*time.Minute, nil, time.Microsecond)
+	if eq, _ := df.Series[0].IsEqual(ctx, expected); !eq {
+		t.Errorf("wrong val: expected: %v actual: %v", expected, df.Series[0])
+	}
+}
@@ -7,7 +7,9 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"reflect"
 	"strconv"
+	"strings"
 	"time"
 
 	dataframe "github.com/rocketlaunchr/dataframe-go"
@@ -23,8 +25,47 @@ const (
 	PostgreSQL Database = 0
 	// MySQL database
 	MySQL Database = 1
+	// SQLite database
+	SQLite Database = 2
+	// MSSQL (Microsoft SQL Server) database
+	MSSQL Database = 3
 )
 
+// sqlTimeLayouts returns the layouts used to parse time values for a given database.
+func sqlTimeLayouts(database Database) []string {
+	switch database {
+	case MySQL:
+		return []string{"2006-01-02 15:04:05"}
+	case SQLite:
+		// SQLite has no time type. Values are usually stored as text.
+		return []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02T15:04:05", "2006-01-02"}
+	case MSSQL:
+		return []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02T15:04:05"}
+	default:
+		return []string{time.RFC3339} // Default for PostgreSQL
+	}
+}
+
+// sqlParseTime parses a time value for a given database.
+// If the value can't be parsed, it is assumed to be a unix timestamp.
+func sqlParseTime(database Database, val string) (time.Time, error) {
+
+	layouts := sqlTimeLayouts(database)
+	for _, layout := range layouts {
+		t, err := time.Parse(layout, val)
+		if err == nil {
+			return t, nil
+		}
+	}
+
+	// Assume unix timestamp
+	sec, err := strconv.ParseInt(val, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("can't force string: %s to time.Time (%s)", val, strings.Join(layouts, ", "))
+	}
+	return time.Unix(sec, 0), nil
+}
+
 type queryContexter1 interface {
 	QueryContext(ctx context.Context, args ...interface{}) (*sql.Rows, error)
 }
@@ -78,11 +119,105 @@ type SQLLoadOptions struct {
 	Query string
 }
 
+// sqlKind is the data type of a column, which determines the Series used.
+type sqlKind int
+
+const (
+	sqlString sqlKind = iota
+	sqlFloat
+	sqlInt
+	sqlBool
+	sqlTime
+)
+
+// sqlColumnKind determines the data type of a column from the driver's metadata.
+// The database type name is used if it is recognized. Otherwise the scan type is used.
+func sqlColumnKind(ct *sql.ColumnType) sqlKind {
+
+	switch ct.DatabaseTypeName() {
+	case "VARCHAR", "TEXT", "NVARCHAR", "MEDIUMTEXT", "LONGTEXT", "CHAR", "NCHAR", "NTEXT":
+		return sqlString
+	case "FLOAT", "FLOAT4", "FLOAT8", "DOUBLE", "DECIMAL", "NUMERIC", "REAL", "MONEY", "SMALLMONEY":
+		return sqlFloat
+	case "INT", "INTEGER", "TINYINT", "INT2", "INT4", "INT8", "MEDIUMINT", "SMALLINT", "BIGINT":
+		return sqlInt
+	case "BOOL", "BOOLEAN":
+		return sqlBool
+	case "BIT":
+		// BIT is only a bool for some drivers (eg. MSSQL). Otherwise it is raw bytes or a bit string.
+		if st := ct.ScanType(); st != nil && (st.Kind() == reflect.Bool || st == reflect.TypeOf(sql.NullBool{})) {
+			return sqlBool
+		}
+		return sqlString
+	case "DATETIME", "TIMESTAMP", "TIMESTAMPTZ", "DATETIME2", "SMALLDATETIME", "DATETIMEOFFSET":
+		return sqlTime
+	}
+
+	// Use scan type if info is available
+	st := ct.ScanType()
+	if st == nil {
+		return sqlString
+	}
+
+	switch st {
+	case reflect.TypeOf(sql.NullInt64{}), reflect.TypeOf(sql.NullInt32{}):
+		return sqlInt
+	case reflect.TypeOf(sql.NullFloat64{}):
+		return sqlFloat
+	case reflect.TypeOf(sql.NullBool{}):
+		return sqlBool
+	case reflect.TypeOf(sql.NullTime{}), reflect.TypeOf(time.Time{}):
+		return sqlTime
+	}
+
+	switch st.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return sqlInt
+	case reflect.Float32, reflect.Float64:
+		return sqlFloat
+	case reflect.Bool:
+		return sqlBool
+	}
+
+	// Drivers may use their own nullable time type (eg. mysql.NullTime)
+	if st.Kind() == reflect.Struct {
+		if f, exists := st.FieldByName("Time"); exists && f.Type == reflect.TypeOf(time.Time{}) {
+			return sqlTime
+		}
+	}
+
+	return sqlString
+}
+
 // LoadFromSQL will load data from a sql database.
 // stmt must be a *sql.Stmt or the equivalent from the mysql-go package.
 //
+// Unless dictated by DictateDataType, the data type of each column is determined from the driver's metadata
+// (sql.ColumnType's DatabaseTypeName and ScanType). Integer and boolean columns are loaded as SeriesInt64,
+// floating point and decimal columns as SeriesFloat64, time columns as SeriesTime and all other columns as SeriesString.
+//
 // See: https://godoc.org/github.com/rocketlaunchr/mysql-go#Stmt
 func LoadFromSQL(ctx context.Context, stmt interface{}, options *SQLLoadOptions, args ...interface{}) (*dataframe.DataFrame, error) {
+	return loadFromSQL(ctx, stmt, options, 0, nil, args...)
+}
+
+// StreamFromSQL will load data from a sql database in chunks of (at most) chunkSize rows.
+// Each chunk is provided to fn as a separate Dataframe. This allows result sets larger than memory to be processed.
+// If fn returns an error, then no more chunks are loaded and the error is returned.
+//
+// KnownRowCount is ignored. See LoadFromSQL for details.
+func StreamFromSQL(ctx context.Context, stmt interface{}, options *SQLLoadOptions, chunkSize int, fn func(df *dataframe.DataFrame) error, args ...interface{}) error {
+
+	if chunkSize <= 0 {
+		return errors.New("chunkSize must be greater than 0")
+	}
+
+	_, err := loadFromSQL(ctx, stmt, options, chunkSize, fn, args...)
+	return err
+}
+
+func loadFromSQL(ctx context.Context, stmt interface{}, options *SQLLoadOptions, chunkSize int, fn func(df *dataframe.DataFrame) error, args ...interface{}) (*dataframe.DataFrame, error) {
 
 	var (
 		init     *dataframe.SeriesInit
@@ -93,18 +228,23 @@ func LoadFromSQL(ctx context.Context, stmt interface{}, options *SQLLoadOptions,
 
 	if options != nil {
 
-		if options.KnownRowCount != nil {
+		if options.KnownRowCount != nil && chunkSize == 0 {
 			init = &dataframe.SeriesInit{
 				Size: *options.KnownRowCount,
 			}
 		}
 
 		database = options.Database
-		if database != PostgreSQL && database != MySQL {
+		if database != PostgreSQL && database != MySQL && database != SQLite && database != MSSQL {
 			return nil, errors.New("invalid database")
 		}
 	}
 
+	var chunkInit *dataframe.SeriesInit
+	if chunkSize > 0 {
+		chunkInit = &dataframe.SeriesInit{Capacity: chunkSize}
+	}
+
 	var (
 		rows rows
 		err  error
@@ -143,61 +283,71 @@ func LoadFromSQL(ctx context.Context, stmt interface{}, options *SQLLoadOptions,
 		return nil, errors.New("no series found")
 	}
 
-	// Create the dataframe
-	seriess := []dataframe.Series{}
-	for _, ct := range cols { // ct is ColumnType
-		name := ct.Name()
-		typ := ct.DatabaseTypeName()
-
-		// Check if data type is dictated and use if available
-		if options != nil && len(options.DictateDataType) > 0 {
-			if dtyp, exists := options.DictateDataType[name]; exists {
-
-				switch T := dtyp.(type) {
-				case float64:
-					seriess = append(seriess, dataframe.NewSeriesFloat64(name, init))
-				case int64, bool:
-					seriess = append(seriess, dataframe.NewSeriesInt64(name, init))
-				case string:
-					seriess = append(seriess, dataframe.NewSeriesString(name, init))
-				case time.Time:
-					seriess = append(seriess, dataframe.NewSeriesTime(name, init))
-				case dataframe.NewSerieser:
-					seriess = append(seriess, T.NewSeries(name, init))
-				case Converter:
-					switch T.ConcreteType.(type) {
+	kinds := make([]sqlKind, totalColumns)
+	for i, ct := range cols {
+		kinds[i] = sqlColumnKind(ct)
+	}
+
+	// newDataFrame creates an empty dataframe
+	newDataFrame := func(init *dataframe.SeriesInit) *dataframe.DataFrame {
+
+		seriess := []dataframe.Series{}
+		for i, ct := range cols { // ct is ColumnType
+			name := ct.Name()
+
+			// Check if data type is dictated and use if available
+			if options != nil && len(options.DictateDataType) > 0 {
+				if dtyp, exists := options.DictateDataType[name]; exists {
+
+					switch T := dtyp.(type) {
+					case float64:
+						seriess = append(seriess, dataframe.NewSeriesFloat64(name, init))
+					case int64, bool:
+						seriess = append(seriess, dataframe.NewSeriesInt64(name, init))
+					case string:
+						seriess = append(seriess, dataframe.NewSeriesString(name, init))
 					case time.Time:
 						seriess = append(seriess, dataframe.NewSeriesTime(name, init))
+					case dataframe.NewSerieser:
+						seriess = append(seriess, T.NewSeries(name, init))
+					case Converter:
+						switch T.ConcreteType.(type) {
+						case time.Time:
+							seriess = append(seriess, dataframe.NewSeriesTime(name, init))
+						default:
+							seriess = append(seriess, dataframe.NewSeriesGeneric(name, T.ConcreteType, init))
+						}
 					default:
-						seriess = append(seriess, dataframe.NewSeriesGeneric(name, T.ConcreteType, init))
+						seriess = append(seriess, dataframe.NewSeriesGeneric(name, dtyp, init))
 					}
-				default:
-					seriess = append(seriess, dataframe.NewSeriesGeneric(name, typ, init))
-				}
 
-				continue
+					continue
+				}
 			}
-		}
 
-		// Use typ if info is available
-		switch typ {
-		case "VARCHAR", "TEXT", "NVARCHAR", "MEDIUMTEXT", "LONGTEXT":
-			seriess = append(seriess, dataframe.NewSeriesString(name, init))
-		case "FLOAT", "FLOAT4", "FLOAT8", "DOUBLE", "DECIMAL", "NUMERIC":
-			seriess = append(seriess, dataframe.NewSeriesFloat64(name, init))
-		case "BOOL", "INT", "TINYINT", "INT2", "INT4", "INT8", "MEDIUMINT", "SMALLINT", "BIGINT":
-			seriess = append(seriess, dataframe.NewSeriesInt64(name, init))
-		case "DATETIME", "TIMESTAMP", "TIMESTAMPTZ":
-			seriess = append(seriess, dataframe.NewSeriesTime(name, init))
-		case "":
-			// Assume string
-			seriess = append(seriess, dataframe.NewSeriesString(name, init))
-		default: // assume string if info is not available
-			seriess = append(seriess, dataframe.NewSeriesString(name, init))
+			switch kinds[i] {
+			case sqlFloat:
+				seriess = append(seriess, dataframe.NewSeriesFloat64(name, init))
+			case sqlInt:
+				seriess = append(seriess, dataframe.NewSeriesInt64(name, init))
+			case sqlBool:
+				s := dataframe.NewSeriesInt64(name, init)
+				s.SetValueToStringFormatter(dataframe.BoolValueFormatter)
+				seriess = append(seriess, s)
+			case sqlTime:
+				seriess = append(seriess, dataframe.NewSeriesTime(name, init))
+			default: // assume string if info is not available
+				seriess = append(seriess, dataframe.NewSeriesString(name, init))
+			}
 		}
+		return dataframe.NewDataFrame(seriess...)
+	}
 
+	if chunkSize > 0 {
+		df = newDataFrame(chunkInit)
+	} else {
+		df = newDataFrame(init)
 	}
-	df = dataframe.NewDataFrame(seriess...)
 
 	for rows.Next() {
 		row++
@@ -214,7 +364,6 @@ func LoadFromSQL(ctx context.Context, stmt interface{}, options *SQLLoadOptions,
 		insertVals := map[string]interface{}{}
 		for colID, elem := range rowData {
 
-			colType := cols[colID].DatabaseTypeName()
 			fieldName := cols[colID].Name()
 
 			var val *string
@@ -256,19 +405,9 @@ func LoadFromSQL(ctx context.Context, stmt interface{}, options *SQLLoadOptions,
 							return nil, fmt.Errorf("can't force string: %s to bool. row: %d field: %s", *val, row-1, fieldName)
 						}
 					case time.Time:
-						layout := time.RFC3339 // Default for PostgreSQL
-						if database == MySQL {
-							layout = "2006-01-02 15:04:05"
-						}
-
-						t, err := time.Parse(layout, *val)
+						t, err := sqlParseTime(database, *val)
 						if err != nil {
-							// Assume unix timestamp
-							sec, err := strconv.ParseInt(*val, 10, 64)
-							if err != nil {
-								return nil, fmt.Errorf("can't force string: %s to time.Time (%s). row: %d field: %s", *val, layout, row-1, fieldName)
-							}
-							t = time.Unix(sec, 0)
+							return nil, fmt.Errorf("%v. row: %d field: %s", err, row-1, fieldName)
 						}
 						insertVals[fieldName] = t
 					case dataframe.NewSerieser:
@@ -287,22 +426,20 @@ func LoadFromSQL(ctx context.Context, stmt interface{}, options *SQLLoadOptions,
 				}
 			}
 
-			switch colType {
-			case "VARCHAR", "TEXT", "NVARCHAR", "MEDIUMTEXT", "LONGTEXT":
-				insertVals[fieldName] = *val
-			case "FLOAT", "DOUBLE", "DECIMAL", "NUMERIC", "FLOAT4", "FLOAT8":
+			switch kinds[colID] {
+			case sqlFloat:
 				f, err := strconv.ParseFloat(*val, 64)
 				if err != nil {
 					return nil, fmt.Errorf("can't force string: %s to float64. row: %d field: %s", *val, row-1, fieldName)
 				}
 				insertVals[fieldName] = f
-			case "INT", "TINYINT", "INT2", "INT4", "INT8", "MEDIUMINT", "SMALLINT", "BIGINT":
+			case sqlInt:
 				n, err := strconv.ParseInt(*val, 10, 64)
 				if err != nil {
 					return nil, fmt.Errorf("can't force string: %s to Int. row: %d field: %s", *val, row-1, fieldName)
 				}
 				insertVals[fieldName] = n
-			case "BOOL":
+			case sqlBool:
 				if *val == "true" || *val == "TRUE" || *val == "True" || *val == "1" {
 					insertVals[fieldName] = int64(1)
 				} else if *val == "false" || *val == "FALSE" || *val == "False" || *val == "0" {
@@ -310,20 +447,10 @@ func LoadFromSQL(ctx context.Context, stmt interface{}, options *SQLLoadOptions,
 				} else {
 					return nil, fmt.Errorf("can't force string: %s to bool. row: %d field: %s", *val, row-1, fieldName)
 				}
-			case "DATETIME", "TIMESTAMP", "TIMESTAMPTZ":
-				layout := time.RFC3339 // Default for PostgreSQL
-				if database == MySQL {
-					layout = "2006-01-02 15:04:05"
-				}
-
-				t, err := time.Parse(layout, *val)
+			case sqlTime:
+				t, err := sqlParseTime(database, *val)
 				if err != nil {
-					// Assume unix timestamp
-					sec, err := strconv.ParseInt(*val, 10, 64)
-					if err != nil {
-						return nil, fmt.Errorf("can't force string: %s to time.Time (%s). row: %d field: %s", *val, layout, row-1, fieldName)
-					}
-					t = time.Unix(sec, 0)
+					return nil, fmt.Errorf("%v. row: %d field: %s", err, row-1, fieldName)
 				}
 				insertVals[fieldName] = t
 			default:
@@ -332,6 +459,18 @@ func LoadFromSQL(ctx context.Context, stmt interface{}, options *SQLLoadOptions,
 			}
 		}
 
+		if chunkSize > 0 {
+			df.Append(&dataframe.DontLock, insertVals)
+
+			if df.NRows(dataframe.DontLock) == chunkSize {
+				if err := fn(df); err != nil {
+					return nil, err
+				}
+				df = newDataFrame(chunkInit)
+			}
+			continue
+		}
+
 		if init == nil {
 			df.Append(&dataframe.DontLock, make([]interface{}, len(df.Series))...)
 		}
@@ -342,6 +481,16 @@ func LoadFromSQL(ctx context.Context, stmt interface{}, options *SQLLoadOptions,
 		return nil, err
 	}
 
+	if chunkSize > 0 {
+		// Remaining rows
+		if df.NRows(dataframe.DontLock) > 0 {
+			if err := fn(df); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	}
+
 	if df == nil {
 		return nil, dataframe.ErrNoRows
 	}
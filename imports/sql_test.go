@@ -0,0 +1,166 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package imports
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+
+	dataframe "github.com/rocketlaunchr/dataframe-go"
+)
+
+// testDriver is a minimal database driver which returns a fixed result set (based on the query).
+type testDriver struct{}
+
+func (testDriver) Open(name string) (driver.Conn, error) { return testConn{}, nil }
+
+type testConn struct{}
+
+func (testConn) Prepare(query string) (driver.Stmt, error) { return testStmt{query}, nil }
+func (testConn) Close() error                              { return nil }
+func (testConn) Begin() (driver.Tx, error)                 { return nil, driver.ErrSkip }
+
+type testStmt struct {
+	query string
+}
+
+func (testStmt) Close() error                                    { return nil }
+func (testStmt) NumInput() int                                   { return -1 }
+func (testStmt) Exec(args []driver.Value) (driver.Result, error) { return nil, driver.ErrSkip }
+func (s testStmt) Query(args []driver.Value) (driver.Rows, error) {
+	if s.query == "BIT" {
+		// BIT columns as returned by the MySQL, PostgreSQL and MSSQL drivers
+		return &testRows{
+			columns:   []string{"mysql", "postgres", "mssql"},
+			dbTypes:   []string{"BIT", "BIT", "BIT"},
+			scanTypes: []reflect.Type{reflect.TypeOf(sql.RawBytes{}), reflect.TypeOf(new(interface{})).Elem(), reflect.TypeOf(true)},
+			data: [][]driver.Value{
+				{[]byte{1}, "101", true},
+				{[]byte{0}, "010", false},
+				{nil, nil, nil},
+			},
+		}, nil
+	}
+
+	return &testRows{
+		columns: []string{"int", "float", "bool", "time", "string"},
+		scanTypes: []reflect.Type{
+			reflect.TypeOf(sql.NullInt64{}),
+			reflect.TypeOf(float64(0)),
+			reflect.TypeOf(sql.NullBool{}),
+			reflect.TypeOf(sql.NullTime{}),
+			reflect.TypeOf(""),
+		},
+		data: [][]driver.Value{
+			{int64(1), 1.5, true, time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC), "a"},
+			{nil, 2.5, false, nil, nil},
+			{int64(3), nil, nil, time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC), "c"},
+		},
+	}, nil
+}
+
+type testRows struct {
+	columns   []string
+	dbTypes   []string
+	scanTypes []reflect.Type
+	data      [][]driver.Value
+	row       int
+}
+
+func (r *testRows) Columns() []string { return r.columns }
+func (r *testRows) Close() error      { return nil }
+func (r *testRows) Next(dest []driver.Value) error {
+	if r.row >= len(r.data) {
+		return io.EOF
+	}
+	copy(dest, r.data[r.row])
+	r.row++
+	return nil
+}
+
+func (r *testRows) ColumnTypeScanType(index int) reflect.Type {
+	return r.scanTypes[index]
+}
+
+func (r *testRows) ColumnTypeDatabaseTypeName(index int) string {
+	if r.dbTypes == nil {
+		return ""
+	}
+	return r.dbTypes[index]
+}
+
+func init() {
+	sql.Register("dataframe-test", testDriver{})
+}
+
+func TestSQLImport(t *testing.T) {
+
+	db, err := sql.Open("dataframe-test", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	t1 := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	t2 := time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	expDf := dataframe.NewDataFrame(
+		dataframe.NewSeriesInt64("int", nil, 1, nil, 3),
+		dataframe.NewSeriesFloat64("float", nil, 1.5, 2.5, nil),
+		dataframe.NewSeriesInt64("bool", nil, 1, 0, nil),
+		dataframe.NewSeriesTime("time", nil, t1, nil, t2),
+		dataframe.NewSeriesString("string", nil, "a", nil, "c"),
+	)
+
+	df, err := LoadFromSQL(ctx, db, &SQLLoadOptions{Query: "SELECT"})
+	if err != nil {
+		t.Fatalf("sql import error: %v", err)
+	}
+
+	if eq, err := df.IsEqual(ctx, expDf, dataframe.IsEqualOptions{CheckName: true}); !eq {
+		t.Errorf("sql import not equal: %v\n%v", err, df.Table())
+	}
+
+	// Streaming
+	chunks := []int{}
+	err = StreamFromSQL(ctx, db, &SQLLoadOptions{Query: "SELECT"}, 2, func(df *dataframe.DataFrame) error {
+		chunks = append(chunks, df.NRows())
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("sql stream error: %v", err)
+	}
+
+	if !reflect.DeepEqual(chunks, []int{2, 1}) {
+		t.Errorf("sql stream chunks: %v", chunks)
+	}
+}
+
+func TestSQLImportBit(t *testing.T) {
+
+	db, err := sql.Open("dataframe-test", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	// Only a BIT column scanned as a bool is loaded as a bool
+	expDf := dataframe.NewDataFrame(
+		dataframe.NewSeriesString("mysql", nil, "\x01", "\x00", nil),
+		dataframe.NewSeriesString("postgres", nil, "101", "010", nil),
+		dataframe.NewSeriesInt64("mssql", nil, 1, 0, nil),
+	)
+
+	df, err := LoadFromSQL(ctx, db, &SQLLoadOptions{Query: "BIT"})
+	if err != nil {
+		t.Fatalf("sql import error: %v", err)
+	}
+
+	if eq, err := df.IsEqual(ctx, expDf, dataframe.IsEqualOptions{CheckName: true}); !eq {
+		t.Errorf("sql import not equal: %v\n%v", err, df.Table())
+	}
+}
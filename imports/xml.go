@@ -0,0 +1,348 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package imports
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+
+	dataframe "github.com/rocketlaunchr/dataframe-go"
+	"golang.org/x/net/html/charset"
+)
+
+// XMLColumn describes the location of a field relative to each row element.
+type XMLColumn struct {
+
+	// Name is the name of the field.
+	Name string
+
+	// Path is the location of the value relative to the row element.
+	// It is a slash-separated list of child element names, optionally ending with an attribute (prefixed with "@").
+	// The text of the final element is used if an attribute is not provided. The first matching element is used.
+	// An empty Path or "." refers to the text of the row element itself.
+	//
+	// Example: "@id", "name", "address/city", "address/@country"
+	Path string
+
+	// Type can be used to dictate the true underlying data type of the field.
+	// It accepts the same values as DictateDataType, which takes precedence.
+	Type interface{}
+}
+
+// XMLLoadOptions is likely to change.
+type XMLLoadOptions struct {
+
+	// RowPath selects the elements that represent rows. It is a slash-separated list of element names.
+	// When it begins with a single "/", the path is absolute (starting from the root element).
+	// Otherwise, it matches elements at any depth. A "*" matches any element name.
+	// Rows nested within another row are ignored.
+	//
+	// The default is "/*/*" (i.e. the children of the root element).
+	//
+	// Example: "/feed/entry", "//record", "record"
+	RowPath string
+
+	// Columns describes the location of each field.
+	// If not set, the attributes and direct child elements of each row element are used
+	// (in order of first appearance), named by their local name. An attribute that shares its name with
+	// a child element is named with a "@" prefix.
+	Columns []XMLColumn
+
+	// DictateDataType is used to inform LoadFromXML what the true underlying data type is for a given field name.
+	// The key must be the case-sensitive field name.
+	// The value for a given key must be of the data type of the data.
+	// eg. For a string use "". For a int64 use int64(0). What is relevant is the data type and not the value itself.
+	//
+	// NOTE: A custom Series must implement NewSerieser interface and be able to interpret strings to work.
+	DictateDataType map[string]interface{}
+
+	// NilValue allows you to set what string value in the file should be interpreted as a nil value for
+	// the purposes of insertion. Missing and blank fields are always interpreted as nil.
+	//
+	// Common values are: NULL, \N, NaN, NA
+	NilValue *string
+
+	// InferDataTypes can be set to true if the underlying data type should be automatically detected.
+	// Using DictateDataType is the recommended approach (especially for large datasets or memory constrained systems).
+	// DictateDataType always takes precedence when determining the type.
+	// If the data type could not be detected, NewSeriesString is used.
+	InferDataTypes bool
+}
+
+// xmlNode is a simplified representation of an element.
+type xmlNode struct {
+	name     string
+	attrs    []xml.Attr
+	children []*xmlNode
+	text     strings.Builder
+}
+
+// LoadFromXML will load data from a xml file.
+// Namespaces are ignored when matching element and attribute names.
+// Non UTF-8 encodings (eg. ISO-8859-1) are supported if declared in the xml declaration.
+//
+// Example:
+//
+//  <feed>
+//     <entry id="1"><name>Spain</name><address country="ES"><city>Madrid</city></address></entry>
+//     <entry id="2"><name>Japan</name><address country="JP"><city>Tokyo</city></address></entry>
+//  </feed>
+//
+//  opts := imports.XMLLoadOptions{
+//     RowPath: "/feed/entry",
+//     Columns: []imports.XMLColumn{
+//        {Name: "id", Path: "@id", Type: int64(0)},
+//        {Name: "name", Path: "name"},
+//        {Name: "city", Path: "address/city"},
+//        {Name: "country", Path: "address/@country"},
+//     },
+//  }
+//
+func LoadFromXML(ctx context.Context, r io.Reader, options ...XMLLoadOptions) (*dataframe.DataFrame, error) {
+
+	var opts XMLLoadOptions
+	if len(options) > 0 {
+		opts = options[0]
+	}
+
+	rowPath := opts.RowPath
+	if rowPath == "" {
+		rowPath = "/*/*"
+	}
+
+	absolute := strings.HasPrefix(rowPath, "/") && !strings.HasPrefix(rowPath, "//")
+	steps := strings.Split(strings.Trim(rowPath, "/"), "/")
+	for _, step := range steps {
+		if step == "" {
+			return nil, errors.New("invalid RowPath")
+		}
+	}
+
+	// Find the row elements
+	rows := []*xmlNode{}
+
+	dec := xml.NewDecoder(r)
+	dec.CharsetReader = charset.NewReaderLabel // eg. ISO-8859-1, windows-1252
+	stack := []string{}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		token, err := dec.Token()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			stack = append(stack, t.Name.Local)
+			if xmlPathMatch(stack, steps, absolute) {
+				node, err := xmlReadNode(dec, t)
+				if err != nil {
+					return nil, err
+				}
+				rows = append(rows, node)
+				stack = stack[:len(stack)-1]
+			}
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		}
+	}
+
+	cols := opts.Columns
+	if len(cols) == 0 {
+		cols = xmlInferColumns(rows)
+	}
+	if len(cols) == 0 {
+		return nil, dataframe.ErrNoRows
+	}
+
+	// Extract fields. A value is either nil or a string.
+	nRows := len(rows)
+
+	vals := make([][]interface{}, len(cols))
+	for i := range vals {
+		vals[i] = make([]interface{}, 0, nRows)
+	}
+
+	for _, node := range rows {
+		for idx, c := range cols {
+			v, exists := xmlValue(node, c.Path)
+
+			if !exists || strings.TrimSpace(v) == "" || (opts.NilValue != nil && v == *opts.NilValue) {
+				vals[idx] = append(vals[idx], nil)
+				continue
+			}
+			vals[idx] = append(vals[idx], v)
+		}
+	}
+
+	// Create the series
+	init := &dataframe.SeriesInit{Capacity: nRows}
+	seriess := []dataframe.Series{}
+
+	for idx, c := range cols {
+		var s dataframe.Series
+
+		typ, exists := opts.DictateDataType[c.Name]
+		if !exists && c.Type != nil {
+			typ, exists = c.Type, true
+		}
+
+		if exists {
+			var err error
+			s, err = dictatedSeries(c.Name, typ, vals[idx], init)
+			if err != nil {
+				return nil, err
+			}
+		} else if opts.InferDataTypes {
+			is := newInferSeries(c.Name, &nRows)
+			for _, v := range vals[idx] {
+				is.Insert(0, v)
+			}
+			s, _ = is.inferred()
+		} else {
+			s = dataframe.NewSeriesString(c.Name, init, vals[idx]...)
+		}
+
+		seriess = append(seriess, s)
+	}
+
+	return dataframe.NewDataFrame(seriess...), nil
+}
+
+// xmlPathMatch returns true if the element names in stack match the steps.
+// If absolute is false, steps only need to match the end of the stack.
+func xmlPathMatch(stack []string, steps []string, absolute bool) bool {
+
+	if len(stack) < len(steps) || (absolute && len(stack) != len(steps)) {
+		return false
+	}
+
+	stack = stack[len(stack)-len(steps):]
+	for i, step := range steps {
+		if step != "*" && step != stack[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// xmlReadNode reads the element (including all its descendants) that begins with start.
+func xmlReadNode(dec *xml.Decoder, start xml.StartElement) (*xmlNode, error) {
+
+	node := &xmlNode{name: start.Name.Local, attrs: start.Attr}
+
+	for {
+		token, err := dec.Token()
+		if err != nil {
+			if err == io.EOF {
+				return nil, io.ErrUnexpectedEOF
+			}
+			return nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			child, err := xmlReadNode(dec, t)
+			if err != nil {
+				return nil, err
+			}
+			node.children = append(node.children, child)
+		case xml.CharData:
+			node.text.Write(t)
+		case xml.EndElement:
+			return node, nil
+		}
+	}
+}
+
+// xmlValue returns the value located at path relative to node.
+func xmlValue(node *xmlNode, path string) (string, bool) {
+
+	if path == "" || path == "." {
+		return strings.TrimSpace(node.text.String()), true
+	}
+
+	steps := strings.Split(path, "/")
+	for i, step := range steps {
+
+		if strings.HasPrefix(step, "@") && i == len(steps)-1 {
+			for _, attr := range node.attrs {
+				if attr.Name.Local == step[1:] {
+					return attr.Value, true
+				}
+			}
+			return "", false
+		}
+
+		if step == "." {
+			continue
+		}
+
+		var found *xmlNode
+		for _, child := range node.children {
+			if step == "*" || child.name == step {
+				found = child
+				break
+			}
+		}
+		if found == nil {
+			return "", false
+		}
+		node = found
+	}
+
+	return strings.TrimSpace(node.text.String()), true
+}
+
+// xmlInferColumns returns the attributes and direct child elements of the row elements.
+// If an attribute and a child element share a name, the attribute's column is named by its path (eg. "@id").
+func xmlInferColumns(rows []*xmlNode) []XMLColumn {
+
+	cols := []XMLColumn{}
+	seen := map[string]int{} // path => index of column
+
+	add := func(name, path string) {
+		if _, exists := seen[path]; exists {
+			return
+		}
+		seen[path] = len(cols)
+		cols = append(cols, XMLColumn{Name: name, Path: path})
+
+		// Check for a clash between an attribute and a child element
+		other := "@" + name
+		if strings.HasPrefix(path, "@") {
+			other = name
+		}
+		if idx, exists := seen[other]; exists {
+			if strings.HasPrefix(path, "@") {
+				cols[len(cols)-1].Name = path
+			} else {
+				cols[idx].Name = other
+			}
+		}
+	}
+
+	for _, node := range rows {
+		for _, attr := range node.attrs {
+			if attr.Name.Space == "xmlns" || attr.Name.Local == "xmlns" {
+				continue
+			}
+			add(attr.Name.Local, "@"+attr.Name.Local)
+		}
+		for _, child := range node.children {
+			add(child.name, child.name)
+		}
+	}
+
+	return cols
+}
@@ -0,0 +1,186 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package imports
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	dataframe "github.com/rocketlaunchr/dataframe-go"
+	"github.com/rocketlaunchr/dataframe-go/exports"
+)
+
+func TestXMLImport(t *testing.T) {
+
+	data := `<?xml version="1.0" encoding="UTF-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+	<title>Countries</title>
+	<entry id="1">
+		<name>Spain</name>
+		<address country="ES"><city>Madrid</city></address>
+	</entry>
+	<entry id="2">
+		<name>NA</name>
+		<address country="JP"/>
+	</entry>
+	<entry id="3">
+		<name>Italy</name>
+		<entry id="4"><name>Nested</name></entry>
+	</entry>
+</feed>`
+
+	expDf := dataframe.NewDataFrame(
+		dataframe.NewSeriesInt64("id", nil, 1, 2, 3),
+		dataframe.NewSeriesString("name", nil, "Spain", nil, "Italy"),
+		dataframe.NewSeriesString("city", nil, "Madrid", nil, nil),
+		dataframe.NewSeriesString("country", nil, "ES", "JP", nil),
+	)
+
+	opts := XMLLoadOptions{
+		RowPath: "/feed/entry",
+		Columns: []XMLColumn{
+			{Name: "id", Path: "@id", Type: int64(0)},
+			{Name: "name", Path: "name"},
+			{Name: "city", Path: "address/city"},
+			{Name: "country", Path: "address/@country"},
+		},
+		NilValue: &[]string{"NA"}[0],
+	}
+
+	df, err := LoadFromXML(ctx, strings.NewReader(data), opts)
+	if err != nil {
+		t.Fatalf("xml import error: %v", err)
+	}
+
+	if eq, err := df.IsEqual(ctx, expDf, dataframe.IsEqualOptions{CheckName: true}); !eq {
+		t.Errorf("xml import not equal: %v\n%v", err, df.Table())
+	}
+
+	// Inferred columns
+	data = `<rows><r a="1"><b>2.5</b></r><r a="2"><c>x</c></r></rows>`
+
+	expDf = dataframe.NewDataFrame(
+		dataframe.NewSeriesInt64("a", nil, 1, 2),
+		dataframe.NewSeriesFloat64("b", nil, 2.5, nil),
+		dataframe.NewSeriesString("c", nil, nil, "x"),
+	)
+
+	df, err = LoadFromXML(ctx, strings.NewReader(data), XMLLoadOptions{RowPath: "r", InferDataTypes: true})
+	if err != nil {
+		t.Fatalf("xml import error: %v", err)
+	}
+
+	if eq, err := df.IsEqual(ctx, expDf, dataframe.IsEqualOptions{CheckName: true}); !eq {
+		t.Errorf("xml import not equal: %v\n%v", err, df.Table())
+	}
+}
+
+func TestXMLRoundTrip(t *testing.T) {
+
+	tm := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	df := dataframe.NewDataFrame(
+		dataframe.NewSeriesInt64("int", nil, 1, nil, 3),
+		dataframe.NewSeriesFloat64("float", nil, 1.5, nil, 3.5),
+		dataframe.NewSeriesString("str <&>", nil, "a", nil, "c&d"),
+		dataframe.NewSeriesTime("time", nil, tm, nil, tm.Add(time.Hour)),
+	)
+
+	dictate := map[string]interface{}{
+		"int":     int64(0),
+		"float":   float64(0),
+		"str____": "",
+		"time":    time.Time{},
+	}
+
+	expDf := df.Copy()
+	expDf.Series[2].Rename("str____")
+
+	for _, asAttributes := range []bool{false, true} {
+		var buf bytes.Buffer
+
+		eopts := exports.XMLExportOptions{
+			RootElement:  "items",
+			RowElement:   "item",
+			AsAttributes: asAttributes,
+			Indent:       "  ",
+		}
+
+		if err := exports.ExportToXML(ctx, &buf, df, eopts); err != nil {
+			t.Fatalf("xml export error: %v", err)
+		}
+
+		df2, err := LoadFromXML(ctx, &buf, XMLLoadOptions{RowPath: "/items/item", DictateDataType: dictate})
+		if err != nil {
+			t.Fatalf("xml import error: %v", err)
+		}
+
+		if eq, err := df2.IsEqual(ctx, expDf, dataframe.IsEqualOptions{CheckName: true}); !eq {
+			t.Errorf("xml round trip not equal (attributes: %v): %v\n%v", asAttributes, err, df2.Table())
+		}
+	}
+}
+
+func TestXMLImportCharset(t *testing.T) {
+
+	// "Café" and "Zürich" encoded in ISO-8859-1
+	data := "<?xml version=\"1.0\" encoding=\"ISO-8859-1\"?>\n<rows><r><name>Caf\xe9</name></r><r><name>Z\xfcrich</name></r></rows>"
+
+	df, err := LoadFromXML(ctx, strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("xml import error: %v", err)
+	}
+
+	expected := dataframe.NewSeriesString("name", nil, "Café", "Zürich")
+	if eq, _ := df.Series[0].IsEqual(ctx, expected); !eq {
+		t.Errorf("wrong val: expected: %v actual: %v", expected, df.Series[0])
+	}
+}
+
+func TestXMLNameClash(t *testing.T) {
+
+	// An attribute and a child element share a name
+	data := `<rows><r id="1"><id>a</id></r><r id="2"><id>b</id></r></rows>`
+
+	expDf := dataframe.NewDataFrame(
+		dataframe.NewSeriesString("@id", nil, "1", "2"),
+		dataframe.NewSeriesString("id", nil, "a", "b"),
+	)
+
+	df, err := LoadFromXML(ctx, strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("xml import error: %v", err)
+	}
+
+	if eq, err := df.IsEqual(ctx, expDf, dataframe.IsEqualOptions{CheckName: true}); !eq {
+		t.Errorf("xml import not equal: %v\n%v", err, df.Table())
+	}
+
+	// Series names that convert to the same xml name
+	df = dataframe.NewDataFrame(
+		dataframe.NewSeriesString("a b", nil, "1"),
+		dataframe.NewSeriesString("a_b", nil, "2"),
+		dataframe.NewSeriesString("a?b", nil, "3"),
+	)
+
+	for _, asAttributes := range []bool{false, true} {
+		var buf bytes.Buffer
+
+		if err := exports.ExportToXML(ctx, &buf, df, exports.XMLExportOptions{AsAttributes: asAttributes}); err != nil {
+			t.Fatalf("xml export error: %v", err)
+		}
+
+		df2, err := LoadFromXML(ctx, &buf)
+		if err != nil {
+			t.Fatalf("xml import error (attributes: %v): %v", asAttributes, err)
+		}
+
+		expected := []string{"a_b", "a_b_2", "a_b_3"}
+		if names := df2.Names(); !reflect.DeepEqual(names, expected) {
+			t.Errorf("wrong names (attributes: %v): expected: %v actual: %v", asAttributes, expected, names)
+		}
+	}
+}
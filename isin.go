@@ -0,0 +1,79 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package dataframe
+
+import (
+	"context"
+)
+
+// IsInOptions modifies the behavior of the IsIn function.
+type IsInOptions struct {
+
+	// DontLock can be set to true if the Series or DataFrame should not be locked.
+	DontLock bool
+}
+
+// IsIn returns a SeriesInt64 which indicates whether each value of a Series is contained in values.
+// The returned Series contains 1 (true) or 0 (false) and is displayed using BoolValueFormatter.
+// nil values are only contained in values if values contains nil. When sdf is a DataFrame,
+// each Series is checked and a new DataFrame is returned.
+//
+// Values are compared using Go's equality operator. Numeric values are converted to the type of each Series
+// (eg. an int matches the equivalent float64 value of a SeriesFloat64).
+//
+// See: https://pandas.pydata.org/pandas-docs/stable/reference/api/pandas.Series.isin.html
+func IsIn(ctx context.Context, sdf interface{}, values []interface{}, opts ...IsInOptions) (interface{}, error) {
+
+	if len(opts) == 0 {
+		opts = append(opts, IsInOptions{})
+	}
+
+	set := map[interface{}]interface{}{}
+	for _, v := range values {
+		set[v] = nil
+	}
+
+	isIn := func(s Series) (Series, error) {
+		set := normalizeKeys(set, s)
+		nRows := s.NRows(dontLock)
+
+		out := NewSeriesInt64(s.Name(dontLock), &SeriesInit{Capacity: nRows})
+		out.SetValueToStringFormatter(BoolValueFormatter)
+
+		for row := 0; row < nRows; row++ {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+
+			_, exists := set[s.Value(row, dontLock)]
+			out.Append(B(exists), dontLock)
+		}
+		return out, nil
+	}
+
+	switch typ := sdf.(type) {
+	case Series:
+		if !opts[0].DontLock {
+			typ.Lock()
+			defer typ.Unlock()
+		}
+		return isIn(typ)
+	case *DataFrame:
+		if !opts[0].DontLock {
+			typ.Lock()
+			defer typ.Unlock()
+		}
+
+		seriess := []Series{}
+		for _, s := range typ.Series {
+			ns, err := isIn(s)
+			if err != nil {
+				return nil, err
+			}
+			seriess = append(seriess, ns)
+		}
+		return NewDataFrame(seriess...), nil
+	default:
+		panic("sdf must be a Series or DataFrame")
+	}
+}
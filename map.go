@@ -0,0 +1,172 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package dataframe
+
+import (
+	"context"
+	"time"
+)
+
+// MapFn is used by Map to convert a (non-nil) value to a new value.
+type MapFn func(val interface{}) interface{}
+
+// MapOptions modifies the behavior of the Map function.
+type MapOptions struct {
+
+	// DontLock can be set to true if the Series or DataFrame should not be locked.
+	DontLock bool
+}
+
+// Map converts each value of a Series into a new value and returns the new values as a new Series.
+// mapper can be a map[interface{}]interface{} or a MapFn. When mapper is a map, values that are not found
+// become nil (Numeric keys are converted to the type of each Series, eg. an int key matches the equivalent
+// float64 value of a SeriesFloat64). nil values are not converted and remain nil.
+// When sdf is a DataFrame, each Series is converted and a new DataFrame is returned.
+//
+// The type of the new Series is determined by the new values:
+//
+//  float64 (or a mix of int/int64 and float64): SeriesFloat64
+//  int, int64 or bool: SeriesInt64 (bools are displayed using BoolValueFormatter)
+//  string: SeriesString
+//  time.Time: SeriesTime
+//  time.Duration: SeriesDuration
+//  otherwise: SeriesMixed
+//
+// See: https://pandas.pydata.org/pandas-docs/stable/reference/api/pandas.Series.map.html
+func Map(ctx context.Context, sdf interface{}, mapper interface{}, opts ...MapOptions) (interface{}, error) {
+
+	if len(opts) == 0 {
+		opts = append(opts, MapOptions{})
+	}
+
+	var (
+		fn MapFn
+		m  map[interface{}]interface{}
+	)
+
+	switch _m := mapper.(type) {
+	case map[interface{}]interface{}:
+		m = _m
+	case MapFn:
+		fn = _m
+	case func(val interface{}) interface{}:
+		fn = _m
+	default:
+		panic("mapper must be a map[interface{}]interface{} or MapFn")
+	}
+
+	mapSeries := func(s Series) (Series, error) {
+		fn := fn
+		if m != nil {
+			lookup := normalizeKeys(m, s)
+			fn = func(val interface{}) interface{} {
+				return lookup[val]
+			}
+		}
+
+		nRows := s.NRows(dontLock)
+
+		vals := make([]interface{}, nRows)
+		for row := 0; row < nRows; row++ {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+
+			val := s.Value(row, dontLock)
+			if val != nil {
+				vals[row] = fn(val)
+			}
+		}
+
+		return newInferredSeries(s.Name(dontLock), vals), nil
+	}
+
+	switch typ := sdf.(type) {
+	case Series:
+		if !opts[0].DontLock {
+			typ.Lock()
+			defer typ.Unlock()
+		}
+		return mapSeries(typ)
+	case *DataFrame:
+		if !opts[0].DontLock {
+			typ.Lock()
+			defer typ.Unlock()
+		}
+
+		seriess := []Series{}
+		for _, s := range typ.Series {
+			ns, err := mapSeries(s)
+			if err != nil {
+				return nil, err
+			}
+			seriess = append(seriess, ns)
+		}
+		return NewDataFrame(seriess...), nil
+	default:
+		panic("sdf must be a Series or DataFrame")
+	}
+}
+
+// newInferredSeries returns a new Series containing vals. The type of the Series is determined by vals.
+func newInferredSeries(name string, vals []interface{}) Series {
+
+	var nFloat, nInt, nBool, nString, nTime, nDuration, nOther int
+
+	for _, val := range vals {
+		switch val.(type) {
+		case nil:
+		case float64:
+			nFloat++
+		case int, int64:
+			nInt++
+		case bool:
+			nBool++
+		case string:
+			nString++
+		case time.Time:
+			nTime++
+		case time.Duration:
+			nDuration++
+		default:
+			nOther++
+		}
+	}
+
+	init := &SeriesInit{Capacity: len(vals)}
+
+	var s Series
+
+	switch nonNil := nFloat + nInt + nBool + nString + nTime + nDuration + nOther; {
+	case nonNil == 0:
+		s = NewSeriesMixed(name, init)
+	case nFloat+nInt == nonNil && nFloat > 0:
+		s = NewSeriesFloat64(name, init)
+		for i, val := range vals {
+			if v, ok := val.(int); ok {
+				vals[i] = float64(v)
+			} else if v, ok := val.(int64); ok {
+				vals[i] = float64(v)
+			}
+		}
+	case nInt == nonNil:
+		s = NewSeriesInt64(name, init)
+	case nBool == nonNil:
+		si := NewSeriesInt64(name, init)
+		si.SetValueToStringFormatter(BoolValueFormatter)
+		s = si
+	case nString == nonNil:
+		s = NewSeriesString(name, init)
+	case nTime == nonNil:
+		s = NewSeriesTime(name, init)
+	case nDuration == nonNil:
+		s = NewSeriesDuration(name, init)
+	default:
+		s = NewSeriesMixed(name, init)
+	}
+
+	for _, val := range vals {
+		s.Append(val, dontLock)
+	}
+	return s
+}
@@ -0,0 +1,195 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package dataframe
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"sort"
+)
+
+// NLargestOptions configures NLargest and NSmallest.
+type NLargestOptions struct {
+
+	// Keys sets which Series are used to order the rows of a DataFrame. Rows are compared using the first key
+	// and subsequent keys are only used to break ties. The Desc field of each key is ignored.
+	//
+	// NOTE: This option is required for DataFrames.
+	Keys []SortKey
+
+	// DontLock can be set to true if the Series or DataFrame should not be locked.
+	DontLock bool
+}
+
+// NLargest returns the n rows with the largest values (in descending order) as a new Series or DataFrame.
+// Rows containing a nil value (in any of the keys) are ignored. When values are equal, the rows which appear first are returned.
+// Unlike sorting, it only requires O(N log n) time.
+//
+// NOTE: All Series must implement the NewSerieser interface.
+//
+// See: https://pandas.pydata.org/pandas-docs/stable/reference/api/pandas.DataFrame.nlargest.html
+func NLargest(ctx context.Context, sdf interface{}, n int, opts ...NLargestOptions) (interface{}, error) {
+	return nExtreme(ctx, sdf, n, true, opts...)
+}
+
+// NSmallest returns the n rows with the smallest values (in ascending order) as a new Series or DataFrame.
+// Rows containing a nil value (in any of the keys) are ignored. When values are equal, the rows which appear first are returned.
+// Unlike sorting, it only requires O(N log n) time.
+//
+// NOTE: All Series must implement the NewSerieser interface.
+//
+// See: https://pandas.pydata.org/pandas-docs/stable/reference/api/pandas.DataFrame.nsmallest.html
+func NSmallest(ctx context.Context, sdf interface{}, n int, opts ...NLargestOptions) (interface{}, error) {
+	return nExtreme(ctx, sdf, n, false, opts...)
+}
+
+// rowHeap is a heap of rows where the "worst" row is at the top.
+type rowHeap struct {
+	rows   []int
+	better func(a, b int) bool
+}
+
+func (h *rowHeap) Len() int           { return len(h.rows) }
+func (h *rowHeap) Less(i, j int) bool { return h.better(h.rows[j], h.rows[i]) }
+func (h *rowHeap) Swap(i, j int)      { h.rows[i], h.rows[j] = h.rows[j], h.rows[i] }
+func (h *rowHeap) Push(x interface{}) { h.rows = append(h.rows, x.(int)) }
+func (h *rowHeap) Pop() interface{} {
+	x := h.rows[len(h.rows)-1]
+	h.rows = h.rows[:len(h.rows)-1]
+	return x
+}
+
+func nExtreme(ctx context.Context, sdf interface{}, n int, largest bool, opts ...NLargestOptions) (interface{}, error) {
+
+	if len(opts) == 0 {
+		opts = append(opts, NLargestOptions{})
+	}
+
+	var (
+		keys  []Series
+		nRows int
+	)
+
+	switch typ := sdf.(type) {
+	case Series:
+		if !opts[0].DontLock {
+			typ.Lock()
+			defer typ.Unlock()
+		}
+		keys = []Series{typ}
+		nRows = typ.NRows(dontLock)
+	case *DataFrame:
+		if !opts[0].DontLock {
+			typ.lock.Lock()
+			defer typ.lock.Unlock()
+		}
+		if len(opts[0].Keys) == 0 {
+			panic("Keys is required for a DataFrame")
+		}
+		for _, key := range opts[0].Keys {
+			switch k := key.Key.(type) {
+			case string:
+				col, err := typ.NameToColumn(k, dontLock)
+				if err != nil {
+					return nil, err
+				}
+				keys = append(keys, typ.Series[col])
+			case int:
+				if k < 0 || k >= len(typ.Series) {
+					return nil, fmt.Errorf("column index out of range: %d", k)
+				}
+				keys = append(keys, typ.Series[k])
+			default:
+				return nil, fmt.Errorf("unknown key: %v", k)
+			}
+		}
+		nRows = typ.n
+	default:
+		panic("sdf must be a Series or DataFrame")
+	}
+
+	// better returns true if row a should be returned before row b.
+	better := func(a, b int) bool {
+		for _, s := range keys {
+			va, vb := s.Value(a, dontLock), s.Value(b, dontLock)
+			if s.IsEqualFunc(va, vb) {
+				continue
+			}
+			return s.IsLessThanFunc(va, vb) != largest
+		}
+		return a < b
+	}
+
+	h := &rowHeap{better: better}
+
+	if n > 0 {
+	ROWS:
+		for row := 0; row < nRows; row++ {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+
+			for _, s := range keys {
+				if s.Value(row, dontLock) == nil {
+					continue ROWS
+				}
+			}
+
+			if h.Len() < n {
+				heap.Push(h, row)
+			} else if better(row, h.rows[0]) {
+				h.rows[0] = row
+				heap.Fix(h, 0)
+			}
+		}
+	}
+
+	rows := h.rows
+	sort.Slice(rows, func(i, j int) bool { return better(rows[i], rows[j]) })
+
+	return SelectRows(sdf, rows, dontLock), nil
+}
+
+// SelectRows returns a new Series or DataFrame containing the rows of sdf (in the given order).
+// A row can be provided more than once.
+//
+// NOTE: All Series must implement the NewSerieser interface.
+func SelectRows(sdf interface{}, rows []int, opts ...Options) interface{} {
+
+	switch typ := sdf.(type) {
+	case Series:
+		if len(opts) == 0 || !opts[0].DontLock {
+			typ.Lock()
+			defer typ.Unlock()
+		}
+		return selectRows(typ, rows)
+	case *DataFrame:
+		if len(opts) == 0 || !opts[0].DontLock {
+			typ.lock.RLock()
+			defer typ.lock.RUnlock()
+		}
+		seriess := []Series{}
+		for _, s := range typ.Series {
+			seriess = append(seriess, selectRows(s, rows))
+		}
+		return NewDataFrame(seriess...)
+	default:
+		panic("sdf must be a Series or DataFrame")
+	}
+}
+
+// selectRows returns a new Series containing the rows of s.
+func selectRows(s Series, rows []int) Series {
+
+	ns, ok := s.(NewSerieser)
+	if !ok {
+		panic("s must implement NewSerieser interface")
+	}
+
+	out := ns.NewSeries(s.Name(dontLock), &SeriesInit{Capacity: len(rows)})
+	for _, row := range rows {
+		out.Append(s.Value(row, dontLock), dontLock)
+	}
+	return out
+}
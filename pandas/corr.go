@@ -0,0 +1,226 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package pandas
+
+import (
+	"context"
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/stat"
+
+	dataframe "github.com/rocketlaunchr/dataframe-go"
+)
+
+// CorrMethod sets the method used to calculate the correlation coefficient.
+type CorrMethod int
+
+const (
+	// Pearson is the standard correlation coefficient.
+	Pearson CorrMethod = 0
+
+	// Spearman is the rank correlation coefficient.
+	// See: https://en.wikipedia.org/wiki/Spearman%27s_rank_correlation_coefficient
+	Spearman CorrMethod = 1
+
+	// Kendall is the Kendall Tau-b rank correlation coefficient.
+	// See: https://en.wikipedia.org/wiki/Kendall_rank_correlation_coefficient
+	Kendall CorrMethod = 2
+)
+
+// CorrOptions configures Corr and Cov.
+type CorrOptions struct {
+
+	// MinPeriods sets the minimum number of rows (where both values are not nil) required to produce a result.
+	// Otherwise the result is NaN.
+	MinPeriods int
+
+	// DontLock can be set to true if the DataFrame should not be locked.
+	DontLock bool
+}
+
+// Corr returns the pairwise correlation of all SeriesFloat64 and SeriesInt64 Series in df.
+// Rows where either value is nil are excluded for each pair.
+//
+// The output is a square DataFrame containing a SeriesFloat64 for each Series, named by the Series' name.
+// The nth row corresponds to the nth Series. It can be used as a matrix.Matrix by wrapping it with matrix.MatrixWrap.
+//
+// See: https://pandas.pydata.org/pandas-docs/stable/reference/api/pandas.DataFrame.corr.html
+func Corr(ctx context.Context, df *dataframe.DataFrame, method CorrMethod, opts ...CorrOptions) (*dataframe.DataFrame, error) {
+
+	var fn func(ctx context.Context, x, y []float64) (float64, error)
+
+	switch method {
+	case Pearson:
+		fn = func(ctx context.Context, x, y []float64) (float64, error) {
+			return stat.Correlation(x, y, nil), nil
+		}
+	case Spearman:
+		fn = func(ctx context.Context, x, y []float64) (float64, error) {
+			return stat.Correlation(rank(x), rank(y), nil), nil
+		}
+	case Kendall:
+		fn = kendall
+	default:
+		panic("unknown CorrMethod")
+	}
+
+	return pairwise(ctx, df, fn, opts...)
+}
+
+// Cov returns the pairwise (sample) covariance of all SeriesFloat64 and SeriesInt64 Series in df.
+// Rows where either value is nil are excluded for each pair.
+//
+// The output is a square DataFrame containing a SeriesFloat64 for each Series, named by the Series' name.
+// The nth row corresponds to the nth Series. It can be used as a matrix.Matrix by wrapping it with matrix.MatrixWrap.
+//
+// See: https://pandas.pydata.org/pandas-docs/stable/reference/api/pandas.DataFrame.cov.html
+func Cov(ctx context.Context, df *dataframe.DataFrame, opts ...CorrOptions) (*dataframe.DataFrame, error) {
+	return pairwise(ctx, df, func(ctx context.Context, x, y []float64) (float64, error) {
+		if len(x) < 2 {
+			return math.NaN(), nil
+		}
+		return stat.Covariance(x, y, nil), nil
+	}, opts...)
+}
+
+// pairwise applies fn to every pair of numeric Series in df.
+func pairwise(ctx context.Context, df *dataframe.DataFrame, fn func(ctx context.Context, x, y []float64) (float64, error), opts ...CorrOptions) (*dataframe.DataFrame, error) {
+
+	if len(opts) == 0 {
+		opts = append(opts, CorrOptions{})
+	}
+
+	if !opts[0].DontLock {
+		df.Lock()
+		defer df.Unlock()
+	}
+
+	names := []string{}
+	cols := [][]float64{}
+
+	for _, s := range df.Series {
+		var vals []float64
+
+		switch sf := s.(type) {
+		case *dataframe.SeriesFloat64:
+			vals = sf.Values
+		case *dataframe.SeriesInt64:
+			sf64, err := sf.ToSeriesFloat64(ctx, false)
+			if err != nil {
+				return nil, err
+			}
+			vals = sf64.Values
+		default:
+			continue
+		}
+
+		names = append(names, s.Name())
+		cols = append(cols, vals)
+	}
+
+	n := len(cols)
+	out := make([][]float64, n)
+	for i := range out {
+		out[i] = make([]float64, n)
+	}
+
+	for i := 0; i < n; i++ {
+		for j := i; j < n; j++ {
+
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+
+			// Exclude rows where either value is nil
+			x := []float64{}
+			y := []float64{}
+			for row := range cols[i] {
+				if math.IsNaN(cols[i][row]) || math.IsNaN(cols[j][row]) {
+					continue
+				}
+				x = append(x, cols[i][row])
+				y = append(y, cols[j][row])
+			}
+
+			val := math.NaN()
+			if len(x) > 0 && len(x) >= opts[0].MinPeriods {
+				var err error
+				val, err = fn(ctx, x, y)
+				if err != nil {
+					return nil, err
+				}
+			}
+			out[i][j] = val
+			out[j][i] = val
+		}
+	}
+
+	seriess := []dataframe.Series{}
+	for i, name := range names {
+		seriess = append(seriess, dataframe.NewSeriesFloat64(name, nil, out[i]))
+	}
+
+	return dataframe.NewDataFrame(seriess...), nil
+}
+
+// rank returns the rank of each value. Ties are assigned the average of their ranks.
+func rank(vals []float64) []float64 {
+
+	idx := make([]int, len(vals))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(i, j int) bool { return vals[idx[i]] < vals[idx[j]] })
+
+	out := make([]float64, len(vals))
+	for i := 0; i < len(idx); {
+		j := i
+		for j+1 < len(idx) && vals[idx[j+1]] == vals[idx[i]] {
+			j++
+		}
+
+		r := float64(i+j)/2 + 1
+		for k := i; k <= j; k++ {
+			out[idx[k]] = r
+		}
+		i = j + 1
+	}
+
+	return out
+}
+
+// kendall returns the Kendall Tau-b rank correlation coefficient.
+func kendall(ctx context.Context, x, y []float64) (float64, error) {
+
+	var concordant, discordant, tiesX, tiesY float64
+
+	for i := 0; i < len(x); i++ {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+
+		for j := i + 1; j < len(x); j++ {
+			dx := x[i] - x[j]
+			dy := y[i] - y[j]
+
+			switch {
+			case dx == 0 && dy == 0:
+			case dx == 0:
+				tiesX++
+			case dy == 0:
+				tiesY++
+			case (dx > 0) == (dy > 0):
+				concordant++
+			default:
+				discordant++
+			}
+		}
+	}
+
+	denom := math.Sqrt((concordant + discordant + tiesX) * (concordant + discordant + tiesY))
+	if denom == 0 {
+		return math.NaN(), nil
+	}
+	return (concordant - discordant) / denom, nil
+}
@@ -0,0 +1,131 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package pandas
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	dataframe "github.com/rocketlaunchr/dataframe-go"
+)
+
+var ctx = context.Background()
+
+// floatsEqual returns true if a and b are equal to within tol. NaN values are equal.
+func floatsEqual(a, b []float64, tol float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if math.IsNaN(a[i]) || math.IsNaN(b[i]) {
+			if !math.IsNaN(a[i]) || !math.IsNaN(b[i]) {
+				return false
+			}
+			continue
+		}
+		if math.Abs(a[i]-b[i]) > tol {
+			return false
+		}
+	}
+	return true
+}
+
+func TestCorr(t *testing.T) {
+
+	// c contains ties. The last row is excluded when a is involved.
+	df := dataframe.NewDataFrame(
+		dataframe.NewSeriesFloat64("a", nil, 1, 2, 3, 4, 5, nil),
+		dataframe.NewSeriesFloat64("b", nil, 2, 1, 4, 3, 5, 6),
+		dataframe.NewSeriesString("s", nil, "x", "y", "z", "x", "y", "z"),
+		dataframe.NewSeriesInt64("c", nil, 1, 1, 2, 2, 3, 3),
+	)
+
+	// Expected values are from pandas' DataFrame.corr
+	tests := []struct {
+		method   CorrMethod
+		expected [][]float64
+	}{
+		{
+			Pearson,
+			[][]float64{
+				{1, 0.8, 0.944911182523068},
+				{0.8, 1, 0.9561828874675149},
+				{0.944911182523068, 0.9561828874675149, 1},
+			},
+		},
+		{
+			Spearman,
+			[][]float64{
+				{1, 0.8, 0.9486832980505138},
+				{0.8, 1, 0.9561828874675149},
+				{0.9486832980505138, 0.9561828874675149, 1},
+			},
+		},
+		{
+			Kendall,
+			[][]float64{
+				{1, 0.6, 0.8944271909999159},
+				{0.6, 1, 0.8944271909999159},
+				{0.8944271909999159, 0.8944271909999159, 1},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		out, err := Corr(ctx, df, tc.method)
+		if err != nil {
+			t.Errorf("%d: error encountered: %v", tc.method, err)
+			continue
+		}
+
+		if names := out.Names(); len(names) != 3 || names[0] != "a" || names[1] != "b" || names[2] != "c" {
+			t.Errorf("%d: wrong names: %v", tc.method, names)
+			continue
+		}
+
+		for i, s := range out.Series {
+			actual := s.(*dataframe.SeriesFloat64).Values
+			if !floatsEqual(actual, tc.expected[i], 1e-9) {
+				t.Errorf("%d: wrong val for %s: expected: %v actual: %v", tc.method, s.Name(), tc.expected[i], actual)
+			}
+		}
+	}
+
+	// MinPeriods
+	out, err := Corr(ctx, df, Pearson, CorrOptions{MinPeriods: 6})
+	if err != nil {
+		t.Fatalf("error encountered: %v", err)
+	}
+
+	expected := []float64{math.NaN(), math.NaN(), math.NaN()}
+	if actual := out.Series[0].(*dataframe.SeriesFloat64).Values; !floatsEqual(actual, expected, 0) {
+		t.Errorf("wrong val: expected: %v actual: %v", expected, actual)
+	}
+
+	// Cancelled context
+	cctx, cancel := context.WithCancel(ctx)
+	cancel()
+
+	if _, err := Corr(cctx, df, Kendall); err != context.Canceled {
+		t.Errorf("expected context.Canceled: %v", err)
+	}
+}
+
+func TestCov(t *testing.T) {
+
+	df := dataframe.NewDataFrame(
+		dataframe.NewSeriesFloat64("a", nil, 1, 2, 3, 4, 5, nil),
+		dataframe.NewSeriesFloat64("b", nil, 2, 1, 4, 3, 5, nil),
+	)
+
+	out, err := Cov(ctx, df)
+	if err != nil {
+		t.Fatalf("error encountered: %v", err)
+	}
+
+	expected := []float64{2.5, 2}
+	if actual := out.Series[0].(*dataframe.SeriesFloat64).Values; !floatsEqual(actual, expected, 1e-9) {
+		t.Errorf("wrong val: expected: %v actual: %v", expected, actual)
+	}
+}
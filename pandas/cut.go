@@ -0,0 +1,329 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package pandas
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+
+	dataframe "github.com/rocketlaunchr/dataframe-go"
+)
+
+// CutOptions configures Cut and QCut.
+type CutOptions struct {
+
+	// LeftClosed can be set to true if bins should include their left edge and exclude their right edge, i.e. [a, b).
+	// By default, bins exclude their left edge and include their right edge, i.e. (a, b].
+	LeftClosed bool
+
+	// IncludeLowest can be set to true if the first bin should include its left edge (when not LeftClosed).
+	// It is always true for QCut.
+	IncludeLowest bool
+
+	// Labels sets the label of each bin. It must contain an entry for each bin.
+	// If not set, the interval of each bin is used (eg. "(0, 10]").
+	Labels []string
+
+	// Precision sets the precision of the edges when generating labels. As in pandas, it is the number of
+	// decimal places (counted from the first significant digit for values between -1 and 1).
+	// It is increased if necessary so that the labels are unique. When nil, 3 is used.
+	Precision *int
+
+	// DontLock can be set to true if the Series should not be locked.
+	DontLock bool
+}
+
+// Cut assigns each value of s to a bin and returns the label of the bin along with the bin edges.
+// s must be a SeriesFloat64 or be convertible to one (eg. SeriesInt64).
+// nil values and values outside the bins are assigned nil.
+//
+// bins can be an int, representing the number of equal-width bins spanning the range of s,
+// or a []float64, representing the edges of the bins. The edges must be monotonically increasing.
+// As in pandas, the range of equal-width bins is extended by 0.1% so that the smallest (or largest when
+// LeftClosed) value is included.
+//
+// See: https://pandas.pydata.org/pandas-docs/stable/reference/api/pandas.cut.html
+func Cut(ctx context.Context, s dataframe.Series, bins interface{}, opts ...CutOptions) (*dataframe.SeriesString, []float64, error) {
+
+	if len(opts) == 0 {
+		opts = append(opts, CutOptions{})
+	}
+
+	vals, err := cutValues(ctx, s, opts[0].DontLock)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var edges []float64
+
+	switch b := bins.(type) {
+	case int:
+		if b < 1 {
+			return nil, nil, errors.New("bins must be at least 1")
+		}
+
+		min, max := math.Inf(1), math.Inf(-1)
+		for _, v := range vals {
+			if !math.IsNaN(v) {
+				min = math.Min(min, v)
+				max = math.Max(max, v)
+			}
+		}
+		if math.IsInf(min, 0) {
+			return nil, nil, dataframe.ErrNoRows
+		}
+
+		if min == max {
+			// Widen the range so that the bins have a width
+			adj := 0.001 * math.Abs(min)
+			if adj == 0 {
+				adj = 0.001
+			}
+			min, max = min-adj, max+adj
+		}
+
+		edges = make([]float64, b+1)
+		for i := range edges {
+			edges[i] = min + float64(i)*(max-min)/float64(b)
+		}
+		edges[b] = max
+
+		if opts[0].LeftClosed {
+			edges[b] += 0.001 * (max - min)
+		} else {
+			edges[0] -= 0.001 * (max - min)
+		}
+	case []float64:
+		edges = append([]float64{}, b...)
+	default:
+		panic("bins must be an int or []float64")
+	}
+
+	return cut(ctx, s.Name(), vals, edges, opts[0], false)
+}
+
+// QCut assigns each value of s to a quantile-based bin and returns the label of the bin along with the bin edges.
+// s must be a SeriesFloat64 or be convertible to one (eg. SeriesInt64).
+// nil values are assigned nil.
+//
+// q can be an int, representing the number of quantiles (eg. 4 for quartiles),
+// or a []float64, representing the quantiles (between 0 and 1 inclusive) used as edges.
+// The resultant edges must be unique. The first bin includes its left edge. When LeftClosed, the last bin
+// includes its right edge instead (eg. "[2.5, 4]").
+//
+// See: https://pandas.pydata.org/pandas-docs/stable/reference/api/pandas.qcut.html
+func QCut(ctx context.Context, s dataframe.Series, q interface{}, opts ...CutOptions) (*dataframe.SeriesString, []float64, error) {
+
+	var o CutOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	o.IncludeLowest = true
+
+	vals, err := cutValues(ctx, s, o.DontLock)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var quantiles []float64
+
+	switch _q := q.(type) {
+	case int:
+		if _q < 1 {
+			return nil, nil, errors.New("q must be at least 1")
+		}
+		for i := 0; i <= _q; i++ {
+			quantiles = append(quantiles, float64(i)/float64(_q))
+		}
+	case []float64:
+		quantiles = _q
+	default:
+		panic("q must be an int or []float64")
+	}
+
+	sorted := []float64{}
+	for _, v := range vals {
+		if !math.IsNaN(v) {
+			sorted = append(sorted, v)
+		}
+	}
+	if len(sorted) == 0 {
+		return nil, nil, dataframe.ErrNoRows
+	}
+	sort.Float64s(sorted)
+
+	edges := []float64{}
+	for _, p := range quantiles {
+		if p < 0 || p > 1 {
+			return nil, nil, errors.New("quantiles must be between 0 and 1")
+		}
+		edges = append(edges, quantile(p, sorted))
+	}
+
+	// The last edge is the maximum value, so it must be included when LeftClosed
+	return cut(ctx, s.Name(), vals, edges, o, true)
+}
+
+// cutValues returns the values of s as float64. nil values are returned as NaN.
+func cutValues(ctx context.Context, s dataframe.Series, dontLock bool) ([]float64, error) {
+
+	if !dontLock {
+		s.Lock()
+		defer s.Unlock()
+	}
+
+	switch sf := s.(type) {
+	case *dataframe.SeriesFloat64:
+		return sf.Values, nil
+	case dataframe.ToSeriesFloat64:
+		sf64, err := sf.ToSeriesFloat64(ctx, false)
+		if err != nil {
+			return nil, err
+		}
+		return sf64.Values, nil
+	default:
+		panic(fmt.Sprintf("%T is not convertible to a SeriesFloat64", s))
+	}
+}
+
+// quantile returns the p-quantile of sorted using linear interpolation.
+func quantile(p float64, sorted []float64) float64 {
+	if len(sorted) == 1 {
+		return sorted[0]
+	}
+
+	pos := p * float64(len(sorted)-1)
+	i := int(pos)
+	if i >= len(sorted)-1 {
+		return sorted[len(sorted)-1]
+	}
+	return sorted[i] + (pos-float64(i))*(sorted[i+1]-sorted[i])
+}
+
+// cut assigns each value to a bin. When includeHighest is set, the last bin includes its right edge (when LeftClosed).
+func cut(ctx context.Context, name string, vals []float64, edges []float64, opts CutOptions, includeHighest bool) (*dataframe.SeriesString, []float64, error) {
+
+	if len(edges) < 2 {
+		return nil, nil, errors.New("at least 2 bin edges are required")
+	}
+
+	for i := 1; i < len(edges); i++ {
+		if edges[i] <= edges[i-1] {
+			return nil, nil, errors.New("bin edges must be unique and monotonically increasing")
+		}
+	}
+
+	nBins := len(edges) - 1
+
+	labels := opts.Labels
+	if labels == nil {
+		labels = cutLabels(edges, opts, includeHighest)
+	} else if len(labels) != nBins {
+		return nil, nil, fmt.Errorf("%d labels are required", nBins)
+	}
+
+	out := dataframe.NewSeriesString(name, &dataframe.SeriesInit{Capacity: len(vals)})
+
+	for _, v := range vals {
+
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+
+		bin := -1
+
+		if !math.IsNaN(v) {
+			if opts.LeftClosed {
+				// Find first edge > v
+				idx := sort.Search(len(edges), func(i int) bool { return edges[i] > v })
+				if idx > 0 && idx < len(edges) {
+					bin = idx - 1
+				} else if idx == len(edges) && v == edges[idx-1] && includeHighest {
+					bin = nBins - 1
+				}
+			} else {
+				// Find first edge >= v
+				idx := sort.SearchFloat64s(edges, v)
+				if idx > 0 && idx < len(edges) {
+					bin = idx - 1
+				} else if idx == 0 && v == edges[0] && opts.IncludeLowest {
+					bin = 0
+				}
+			}
+		}
+
+		if bin == -1 {
+			out.Append(nil, dataframe.DontLock)
+		} else {
+			out.Append(labels[bin], dataframe.DontLock)
+		}
+	}
+
+	return out, edges, nil
+}
+
+// cutLabels generates a label for each bin based on its interval.
+func cutLabels(edges []float64, opts CutOptions, includeHighest bool) []string {
+
+	precision := 3
+	if opts.Precision != nil {
+		precision = *opts.Precision
+	}
+
+	// Increase the precision until the rounded edges are unique
+	for ; precision < 20; precision++ {
+		unique := true
+		for i := 1; i < len(edges); i++ {
+			if roundFrac(edges[i-1], precision) == roundFrac(edges[i], precision) {
+				unique = false
+				break
+			}
+		}
+		if unique {
+			break
+		}
+	}
+
+	format := func(v float64) string {
+		return strconv.FormatFloat(roundFrac(v, precision), 'f', -1, 64)
+	}
+
+	labels := []string{}
+	for i := 1; i < len(edges); i++ {
+		a, b := format(edges[i-1]), format(edges[i])
+
+		switch {
+		case opts.LeftClosed && i == len(edges)-1 && includeHighest:
+			labels = append(labels, "["+a+", "+b+"]")
+		case opts.LeftClosed:
+			labels = append(labels, "["+a+", "+b+")")
+		case i == 1 && opts.IncludeLowest:
+			labels = append(labels, "["+a+", "+b+"]")
+		default:
+			labels = append(labels, "("+a+", "+b+"]")
+		}
+	}
+
+	return labels
+}
+
+// roundFrac rounds v to precision decimal places. For values between -1 and 1, the decimal places
+// are counted from the first significant digit.
+func roundFrac(v float64, precision int) float64 {
+
+	if v == 0 || math.IsNaN(v) || math.IsInf(v, 0) {
+		return v
+	}
+
+	digits := precision
+	if whole, frac := math.Modf(v); whole == 0 {
+		digits = -int(math.Floor(math.Log10(math.Abs(frac)))) - 1 + precision
+	}
+
+	pow := math.Pow(10, float64(digits))
+	return math.Round(v*pow) / pow
+}
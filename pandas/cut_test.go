@@ -0,0 +1,132 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package pandas
+
+import (
+	"testing"
+
+	dataframe "github.com/rocketlaunchr/dataframe-go"
+)
+
+func TestCut(t *testing.T) {
+
+	s := dataframe.NewSeriesInt64("x", nil, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, nil)
+	zero := dataframe.NewSeriesFloat64("x", nil, 0, 5, 10, 11, nil)
+
+	// Expected values are from pandas' cut
+	tests := []struct {
+		s        dataframe.Series
+		bins     interface{}
+		opts     CutOptions
+		expected []interface{}
+	}{
+		{
+			s, 3, CutOptions{},
+			[]interface{}{"(0.991, 4]", "(0.991, 4]", "(0.991, 4]", "(0.991, 4]", "(4, 7]", "(4, 7]", "(4, 7]", "(7, 10]", "(7, 10]", "(7, 10]", nil},
+		},
+		{
+			s, 3, CutOptions{LeftClosed: true},
+			[]interface{}{"[1, 4)", "[1, 4)", "[1, 4)", "[4, 7)", "[4, 7)", "[4, 7)", "[7, 10.009)", "[7, 10.009)", "[7, 10.009)", "[7, 10.009)", nil},
+		},
+		{
+			zero, []float64{0, 5, 10}, CutOptions{},
+			[]interface{}{nil, "(0, 5]", "(5, 10]", nil, nil},
+		},
+		{
+			zero, []float64{0, 5, 10}, CutOptions{IncludeLowest: true},
+			[]interface{}{"[0, 5]", "[0, 5]", "(5, 10]", nil, nil},
+		},
+		{
+			zero, []float64{0, 5, 10}, CutOptions{LeftClosed: true},
+			[]interface{}{"[0, 5)", "[5, 10)", nil, nil, nil},
+		},
+		{
+			zero, []float64{0, 5, 10}, CutOptions{Labels: []string{"low", "high"}},
+			[]interface{}{nil, "low", "high", nil, nil},
+		},
+		{
+			dataframe.NewSeriesFloat64("x", nil, 1, 2, 3), 3, CutOptions{},
+			[]interface{}{"(0.998, 1.667]", "(1.667, 2.333]", "(2.333, 3]"},
+		},
+		{
+			// Precision is increased so that the labels are unique
+			dataframe.NewSeriesFloat64("x", nil, 1.00015, 1.5), []float64{1.0001, 1.0002, 2}, CutOptions{},
+			[]interface{}{"(1.0001, 1.0002]", "(1.0002, 2]"},
+		},
+	}
+
+	for i, tc := range tests {
+		out, _, err := Cut(ctx, tc.s, tc.bins, tc.opts)
+		if err != nil {
+			t.Errorf("%d: error encountered: %v", i, err)
+			continue
+		}
+
+		expected := dataframe.NewSeriesString("x", nil, tc.expected...)
+		if eq, _ := out.IsEqual(ctx, expected); !eq {
+			t.Errorf("%d: wrong val: expected: %v actual: %v", i, expected, out)
+		}
+	}
+
+	// Invalid bins
+	if _, _, err := Cut(ctx, s, []float64{0, 5, 5}); err == nil {
+		t.Errorf("expected error for non-unique edges")
+	}
+
+	if _, _, err := Cut(ctx, s, []float64{0, 5, 10}, CutOptions{Labels: []string{"a"}}); err == nil {
+		t.Errorf("expected error for wrong number of labels")
+	}
+}
+
+func TestQCut(t *testing.T) {
+
+	s := dataframe.NewSeriesInt64("x", nil, 1, 2, 3, 4, nil, 5, 6, 7, 8)
+
+	opts := []CutOptions{{}}
+
+	// Expected values are from pandas' qcut
+	out, edges, err := QCut(ctx, s, 4, opts...)
+	if err != nil {
+		t.Fatalf("error encountered: %v", err)
+	}
+
+	expected := dataframe.NewSeriesString("x", nil, "[1, 2.75]", "[1, 2.75]", "(2.75, 4.5]", "(2.75, 4.5]", nil, "(4.5, 6.25]", "(4.5, 6.25]", "(6.25, 8]", "(6.25, 8]")
+	if eq, _ := out.IsEqual(ctx, expected); !eq {
+		t.Errorf("wrong val: expected: %v actual: %v", expected, out)
+	}
+
+	expEdges := []float64{1, 2.75, 4.5, 6.25, 8}
+	if !floatsEqual(edges, expEdges, 1e-9) {
+		t.Errorf("wrong edges: expected: %v actual: %v", expEdges, edges)
+	}
+
+	if opts[0].IncludeLowest {
+		t.Errorf("opts must not be modified")
+	}
+
+	// Quantiles
+	out, _, err = QCut(ctx, s, []float64{0, 0.5, 1})
+	if err != nil {
+		t.Fatalf("error encountered: %v", err)
+	}
+
+	expected = dataframe.NewSeriesString("x", nil, "[1, 4.5]", "[1, 4.5]", "[1, 4.5]", "[1, 4.5]", nil, "(4.5, 8]", "(4.5, 8]", "(4.5, 8]", "(4.5, 8]")
+	if eq, _ := out.IsEqual(ctx, expected); !eq {
+		t.Errorf("wrong val: expected: %v actual: %v", expected, out)
+	}
+
+	// LeftClosed includes the maximum value in the last bin
+	out, _, err = QCut(ctx, dataframe.NewSeriesFloat64("a", nil, 1, 2, 3, 4), 2, CutOptions{LeftClosed: true})
+	if err != nil {
+		t.Fatalf("error encountered: %v", err)
+	}
+
+	expected = dataframe.NewSeriesString("a", nil, "[1, 2.5)", "[1, 2.5)", "[2.5, 4]", "[2.5, 4]")
+	if eq, _ := out.IsEqual(ctx, expected); !eq {
+		t.Errorf("wrong val: expected: %v actual: %v", expected, out)
+	}
+
+	if _, _, err := QCut(ctx, s, []float64{0, 1.5}); err == nil {
+		t.Errorf("expected error for invalid quantile")
+	}
+}
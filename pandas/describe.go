@@ -5,7 +5,9 @@ package pandas
 import (
 	"context"
 	"fmt"
+	"math"
 	"strconv"
+	"time"
 
 	dataframe "github.com/rocketlaunchr/dataframe-go"
 )
@@ -23,56 +25,247 @@ type DescribeOutput struct {
 	Max         []float64
 	Percentiles [][]float64
 
+	// Numeric data (See DescribeExtra)
+	Sum      []float64
+	Variance []float64
+	Skew     []float64
+	Kurtosis []float64
+	MAD      []float64
+
+	// Non-numeric data (See DescribeExtra)
+	Unique []int
+	Top    []interface{}
+	Freq   []int
+
+	// Time data (See DescribeExtra)
+	First []time.Time
+	Last  []time.Time
+	Range []time.Duration
+
 	percentiles []float64
 	headers     []string
+	timeCount   []int // number of non-nil time values (-1 if not a SeriesTime)
 }
 
-// String implements the Stringer interface in the fmt package.
-func (do DescribeOutput) String() string {
+// DescribeExtra sets which additional statistics Describe should return.
+// They can be combined using the bitwise OR operator.
+type DescribeExtra int
 
-	out := map[string][]interface{}{}
+const (
+	// DescribeSum returns the sum of numeric data.
+	DescribeSum DescribeExtra = 1 << iota
+
+	// DescribeVariance returns the sample variance of numeric data.
+	DescribeVariance
+
+	// DescribeSkew returns the sample skewness of numeric data.
+	DescribeSkew
+
+	// DescribeKurtosis returns the sample excess kurtosis of numeric data.
+	DescribeKurtosis
+
+	// DescribeMAD returns the mean absolute deviation (from the mean) of numeric data.
+	DescribeMAD
+
+	// DescribeUnique returns the number of unique values, the most frequent value (top) and its frequency
+	// of non-numeric data (eg. SeriesString).
+	DescribeUnique
+
+	// DescribeTime returns the earliest time (first), latest time (last) and the duration between them (range)
+	// of SeriesTime.
+	DescribeTime
+
+	// DescribeAll returns all additional statistics.
+	DescribeAll = DescribeSum | DescribeVariance | DescribeSkew | DescribeKurtosis | DescribeMAD | DescribeUnique | DescribeTime
+)
+
+// describeRow is a row of the output.
+type describeRow struct {
+	key  string
+	vals []interface{}
+}
+
+// rows returns the statistics of each Series.
+func (do DescribeOutput) rows() []describeRow {
+
+	out := []describeRow{}
+
+	add := func(key string, vals []interface{}) {
+		out = append(out, describeRow{key, vals})
+	}
 
+	floats := func(key string, vals []float64) {
+		if len(vals) == 0 {
+			return
+		}
+		row := []interface{}{}
+		for _, v := range vals {
+			row = append(row, v)
+		}
+		add(key, row)
+	}
+
+	// Standard statistics
+	count := []interface{}{}
+	nilCount := []interface{}{}
 	for idx := range do.headers {
-		out["count"] = append(out["count"], do.Count[idx])
-		out["nil count"] = append(out["nil count"], do.NilCount[idx])
+		count = append(count, do.Count[idx])
+		nilCount = append(nilCount, do.NilCount[idx])
+	}
+	add("count", count)
+	add("nil count", nilCount)
+
+	for _, v := range []struct {
+		key  string
+		vals []float64
+	}{
+		{"median", do.Median},
+		{"mean", do.Mean},
+		{"std dev", do.StdDev},
+		{"min", do.Min},
+		{"max", do.Max},
+	} {
+		row := []interface{}{}
+		for idx := range do.headers {
+			if len(v.vals) > 0 {
+				row = append(row, v.vals[idx])
+			} else {
+				row = append(row, "NaN")
+			}
+		}
+		add(v.key, row)
+	}
 
-		if len(do.Median) > 0 {
-			out["median"] = append(out["median"], do.Median[idx])
-		} else {
-			out["median"] = append(out["median"], "NaN")
+	for i, p := range do.percentiles {
+		key := strconv.FormatFloat(100*p, 'f', -1, 64) + "%"
+		row := []interface{}{}
+		for idx := range do.headers {
+			if idx < len(do.Percentiles) && i < len(do.Percentiles[idx]) {
+				row = append(row, do.Percentiles[idx][i])
+			} else {
+				row = append(row, "NaN")
+			}
 		}
+		add(key, row)
+	}
+
+	// Additional statistics
+	floats("sum", do.Sum)
+	floats("variance", do.Variance)
+	floats("skew", do.Skew)
+	floats("kurtosis", do.Kurtosis)
+	floats("mad", do.MAD)
 
-		if len(do.Mean) > 0 {
-			out["mean"] = append(out["mean"], do.Mean[idx])
-		} else {
-			out["mean"] = append(out["mean"], "NaN")
+	if len(do.Unique) > 0 {
+		unique := []interface{}{}
+		top := []interface{}{}
+		freq := []interface{}{}
+		for idx := range do.headers {
+			if do.Unique[idx] < 0 {
+				unique = append(unique, "NaN")
+				top = append(top, "NaN")
+				freq = append(freq, "NaN")
+				continue
+			}
+			unique = append(unique, do.Unique[idx])
+			top = append(top, do.Top[idx])
+			freq = append(freq, do.Freq[idx])
 		}
+		add("unique", unique)
+		add("top", top)
+		add("freq", freq)
+	}
 
-		if len(do.StdDev) > 0 {
-			out["std dev"] = append(out["std dev"], do.StdDev[idx])
-		} else {
-			out["std dev"] = append(out["std dev"], "NaN")
+	if len(do.First) > 0 {
+		first := []interface{}{}
+		last := []interface{}{}
+		rng := []interface{}{}
+		for idx := range do.headers {
+			if idx >= len(do.timeCount) || do.timeCount[idx] <= 0 {
+				missing := "NaN"
+				if idx < len(do.timeCount) && do.timeCount[idx] == 0 {
+					missing = "NaT" // SeriesTime with only nil values
+				}
+				first = append(first, missing)
+				last = append(last, missing)
+				rng = append(rng, missing)
+				continue
+			}
+			first = append(first, do.First[idx])
+			last = append(last, do.Last[idx])
+			rng = append(rng, do.Range[idx])
 		}
+		add("first", first)
+		add("last", last)
+		add("range", rng)
+	}
+
+	return out
+}
+
+// String implements the Stringer interface in the fmt package.
+func (do DescribeOutput) String() string {
+
+	out := map[string][]interface{}{}
+	for _, row := range do.rows() {
+		out[row.key] = row.vals
+	}
+
+	return printMap(do.headers, out)
+}
 
-		if len(do.Min) > 0 {
-			out["min"] = append(out["min"], do.Min[idx])
-		} else {
-			out["min"] = append(out["min"], "NaN")
+// DataFrame returns the output as a DataFrame. The first Series contains the name of each statistic.
+// Each subsequent Series contains the statistics of a described Series. It is a SeriesFloat64 if all the
+// statistics are numeric. Otherwise it is a SeriesMixed.
+func (do DescribeOutput) DataFrame() *dataframe.DataFrame {
+
+	rows := do.rows()
+
+	stats := dataframe.NewSeriesString("", &dataframe.SeriesInit{Capacity: len(rows)})
+	for _, row := range rows {
+		stats.Append(row.key, dataframe.DontLock)
+	}
+
+	seriess := []dataframe.Series{stats}
+
+	for idx, name := range do.headers {
+
+		vals := []interface{}{}
+		numeric := true
+
+		for _, row := range rows {
+			switch v := row.vals[idx].(type) {
+			case int:
+				vals = append(vals, float64(v))
+			case float64:
+				vals = append(vals, v)
+			case string:
+				if v == "NaN" || v == "NaT" {
+					vals = append(vals, math.NaN())
+				} else {
+					vals = append(vals, v)
+					numeric = false
+				}
+			default:
+				vals = append(vals, v)
+				numeric = false
+			}
 		}
 
-		if len(do.Max) > 0 {
-			out["max"] = append(out["max"], do.Max[idx])
-		} else {
-			out["max"] = append(out["max"], "NaN")
+		if numeric {
+			seriess = append(seriess, dataframe.NewSeriesFloat64(name, nil, vals...))
+			continue
 		}
 
-		for i, p := range do.percentiles {
-			key := strconv.FormatFloat(100*p, 'f', -1, 64) + "%"
-			out[key] = append(out[key], do.Percentiles[idx][i])
+		for i := range vals {
+			if f, ok := vals[i].(float64); ok && math.IsNaN(f) {
+				vals[i] = nil
+			}
 		}
+		seriess = append(seriess, dataframe.NewSeriesMixed(name, nil, vals...))
 	}
 
-	return printMap(do.headers, out)
+	return dataframe.NewDataFrame(seriess...)
 }
 
 // DescribeOptions configures what Describe should return or display.
@@ -86,6 +279,14 @@ type DescribeOptions struct {
 
 	// Blacklist sets which Series to NOT provide statistics for.
 	Blacklist []interface{}
+
+	// Extra sets which additional statistics to return.
+	//
+	// Example:
+	//
+	//  opts := pandas.DescribeOptions{Extra: pandas.DescribeSkew | pandas.DescribeKurtosis}
+	//
+	Extra DescribeExtra
 }
 
 // Describe outputs various statistical information a Series or Dataframe.
@@ -133,6 +133,20 @@ func describeDataframe(ctx context.Context, df *dataframe.DataFrame, opts ...Des
 		} else {
 			out.Percentiles = append(out.Percentiles, []float64{})
 		}
+
+		// Additional statistics
+		out.Sum = append(out.Sum, ldo.Sum...)
+		out.Variance = append(out.Variance, ldo.Variance...)
+		out.Skew = append(out.Skew, ldo.Skew...)
+		out.Kurtosis = append(out.Kurtosis, ldo.Kurtosis...)
+		out.MAD = append(out.MAD, ldo.MAD...)
+		out.Unique = append(out.Unique, ldo.Unique...)
+		out.Top = append(out.Top, ldo.Top...)
+		out.Freq = append(out.Freq, ldo.Freq...)
+		out.First = append(out.First, ldo.First...)
+		out.Last = append(out.Last, ldo.Last...)
+		out.Range = append(out.Range, ldo.Range...)
+		out.timeCount = append(out.timeCount, ldo.timeCount...)
 	}
 
 	return out, nil
@@ -6,7 +6,9 @@ import (
 	"context"
 	"math"
 	"sort"
+	"time"
 
+	"gonum.org/v1/gonum/floats"
 	"gonum.org/v1/gonum/stat"
 
 	dataframe "github.com/rocketlaunchr/dataframe-go"
@@ -43,9 +45,9 @@ func describeSeries(ctx context.Context, s dataframe.Series, opts ...DescribeOpt
 		}
 	}
 
-	if floatable {
-		var vals []float64
+	var vals []float64
 
+	if floatable {
 		// Arrange values from lowest to highest
 		for _, v := range sf.Values {
 			if !math.IsNaN(v) {
@@ -90,5 +92,104 @@ func describeSeries(ctx context.Context, s dataframe.Series, opts ...DescribeOpt
 		}
 	}
 
+	extra := opts[0].Extra
+
+	// Additional statistics for numeric data
+	numeric := func(flag DescribeExtra, minVals int, fn func(vals []float64) float64) []float64 {
+		if extra&flag == 0 {
+			return nil
+		}
+		if len(vals) < minVals {
+			return []float64{math.NaN()}
+		}
+		return []float64{fn(vals)}
+	}
+
+	out.Sum = numeric(DescribeSum, 1, floats.Sum)
+	out.Variance = numeric(DescribeVariance, 2, func(vals []float64) float64 { return stat.Variance(vals, nil) })
+	out.Skew = numeric(DescribeSkew, 3, func(vals []float64) float64 { return stat.Skew(vals, nil) })
+	out.Kurtosis = numeric(DescribeKurtosis, 4, func(vals []float64) float64 { return stat.ExKurtosis(vals, nil) })
+	out.MAD = numeric(DescribeMAD, 1, func(vals []float64) float64 {
+		mean := stat.Mean(vals, nil)
+		var sum float64
+		for _, v := range vals {
+			sum += math.Abs(v - mean)
+		}
+		return sum / float64(len(vals))
+	})
+
+	// Additional statistics for non-numeric data
+	if extra&DescribeUnique != 0 {
+		switch s.(type) {
+		case *dataframe.SeriesFloat64, *dataframe.SeriesInt64, *dataframe.SeriesTime:
+			out.Unique = []int{-1}
+			out.Top = []interface{}{nil}
+			out.Freq = []int{0}
+		default:
+			unique, top, freq := describeUnique(s)
+			out.Unique = []int{unique}
+			out.Top = []interface{}{top}
+			out.Freq = []int{freq}
+		}
+	}
+
+	// Additional statistics for time data
+	if extra&DescribeTime != 0 {
+		var (
+			first, last time.Time
+			count       = -1
+		)
+
+		if st, ok := s.(*dataframe.SeriesTime); ok {
+			count = 0
+			for _, v := range st.Values {
+				if v == nil {
+					continue
+				}
+				if count == 0 || v.Before(first) {
+					first = *v
+				}
+				if count == 0 || v.After(last) {
+					last = *v
+				}
+				count++
+			}
+		}
+
+		out.First = []time.Time{first}
+		out.Last = []time.Time{last}
+		out.Range = []time.Duration{last.Sub(first)}
+		out.timeCount = []int{count}
+	}
+
 	return out, nil
 }
+
+// describeUnique returns the number of unique values, the most frequent value and its frequency.
+// Values are compared using their string representation.
+func describeUnique(s dataframe.Series) (int, interface{}, int) {
+
+	counts := map[string]int{}
+
+	var (
+		top  interface{}
+		freq int
+	)
+
+	nRows := s.NRows()
+	for row := 0; row < nRows; row++ {
+		val := s.Value(row)
+		if val == nil {
+			continue
+		}
+
+		key := s.ValueString(row)
+		counts[key]++
+		if counts[key] > freq {
+			top = val
+			freq = counts[key]
+		}
+	}
+
+	return len(counts), top, freq
+}
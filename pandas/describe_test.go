@@ -0,0 +1,91 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package pandas
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	dataframe "github.com/rocketlaunchr/dataframe-go"
+)
+
+func TestDescribeExtra(t *testing.T) {
+
+	tm := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	df := dataframe.NewDataFrame(
+		dataframe.NewSeriesFloat64("x", nil, 1, 2, nil, 3, 10),
+		dataframe.NewSeriesString("s", nil, "a", "b", "a", nil, nil),
+		dataframe.NewSeriesTime("t", nil, tm.Add(time.Hour), nil, tm, tm.Add(-time.Hour), nil),
+		dataframe.NewSeriesTime("nil", nil, nil, nil, nil, nil, nil),
+	)
+
+	out, err := Describe(ctx, df, DescribeOptions{Extra: DescribeAll})
+	if err != nil {
+		t.Fatalf("error encountered: %v", err)
+	}
+
+	// Expected values are from pandas' Series.sum, var, skew, kurt and mad
+	tests := []struct {
+		name     string
+		expected []float64
+		actual   []float64
+	}{
+		{"sum", []float64{16}, out.Sum[:1]},
+		{"variance", []float64{16.666666666666668}, out.Variance[:1]},
+		{"skew", []float64{1.763632614803888}, out.Skew[:1]},
+		{"kurtosis", []float64{3.228}, out.Kurtosis[:1]},
+		{"mad", []float64{3}, out.MAD[:1]},
+		{"mean", []float64{4}, out.Mean[:1]},
+		{"std dev", []float64{4.08248290463863}, out.StdDev[:1]},
+	}
+
+	for _, tc := range tests {
+		if !floatsEqual(tc.actual, tc.expected, 1e-9) {
+			t.Errorf("wrong %s: expected: %v actual: %v", tc.name, tc.expected, tc.actual)
+		}
+	}
+
+	if out.Unique[1] != 2 || out.Top[1] != "a" || out.Freq[1] != 2 {
+		t.Errorf("wrong unique/top/freq: %v %v %v", out.Unique[1], out.Top[1], out.Freq[1])
+	}
+
+	if !out.First[2].Equal(tm.Add(-time.Hour)) || !out.Last[2].Equal(tm.Add(time.Hour)) || out.Range[2] != 2*time.Hour {
+		t.Errorf("wrong first/last/range: %v %v %v", out.First[2], out.Last[2], out.Range[2])
+	}
+
+	// Not applicable (NaN) vs no time values (NaT)
+	rows := map[string][]interface{}{}
+	for _, row := range out.rows() {
+		rows[row.key] = row.vals
+	}
+
+	for _, key := range []string{"first", "last", "range"} {
+		expected := []interface{}{"NaN", "NaN", rows[key][2], "NaT"}
+		if !reflect.DeepEqual(rows[key], expected) {
+			t.Errorf("wrong %s: expected: %v actual: %v", key, expected, rows[key])
+		}
+	}
+
+	if str := out.String(); !strings.Contains(str, "NaT") {
+		t.Errorf("expected NaT:\n%s", str)
+	}
+
+	// DataFrame output
+	odf := out.DataFrame()
+
+	row := -1
+	for i := 0; i < odf.NRows(); i++ {
+		if odf.Series[0].Value(i) == "first" {
+			row = i
+		}
+	}
+
+	for _, idx := range []int{1, 4} {
+		if v := odf.Series[idx].Value(row); v != nil {
+			t.Errorf("wrong val for %s: expected: nil actual: %v", odf.Series[idx].Name(), v)
+		}
+	}
+}
@@ -0,0 +1,306 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package pandas
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	dataframe "github.com/rocketlaunchr/dataframe-go"
+)
+
+// GetDummiesOptions configures GetDummies.
+type GetDummiesOptions struct {
+
+	// Prefix sets the prefix of the names of the generated Series for a given column.
+	// The key must be the Series name. By default, the Series name is used.
+	Prefix map[string]string
+
+	// PrefixSep sets the separator between the prefix and the value. The default is "_".
+	PrefixSep string
+
+	// DropFirst can be set to true to remove the Series generated for the first value (in sorted order).
+	// This avoids collinearity when the output is used for regression.
+	DropFirst bool
+
+	// DummyNil can be set to true to generate a Series (with the suffix "nil") which indicates nil values.
+	DummyNil bool
+
+	// Float64 can be set to true to generate SeriesFloat64 instead of SeriesInt64.
+	// This is required to use the output with matrix.MatrixWrap.
+	Float64 bool
+
+	// DontLock can be set to true if the DataFrame should not be locked.
+	DontLock bool
+}
+
+// GetDummies converts categorical data into dummy (one-hot encoded) variables.
+// Each of the columns is replaced by a Series (containing 0 or 1) for each unique value. The generated
+// Series are named "<prefix><sep><value>" and are placed after the remaining Series (which are copied).
+// Values are compared using their string representation.
+//
+// columns is a list of Series names or indexes. When nil, all SeriesString are converted.
+// An error is returned if a generated name is not unique (eg. a value of "nil" when DummyNil is set).
+//
+// See: https://pandas.pydata.org/pandas-docs/stable/reference/api/pandas.get_dummies.html
+func GetDummies(ctx context.Context, df *dataframe.DataFrame, columns []interface{}, opts ...GetDummiesOptions) (*dataframe.DataFrame, error) {
+
+	if len(opts) == 0 {
+		opts = append(opts, GetDummiesOptions{})
+	}
+
+	if !opts[0].DontLock {
+		df.Lock()
+		defer df.Unlock()
+	}
+
+	sep := opts[0].PrefixSep
+	if sep == "" {
+		sep = "_"
+	}
+
+	// Determine which Series to convert
+	convert := map[int]struct{}{}
+	if columns == nil {
+		for idx, s := range df.Series {
+			if _, ok := s.(*dataframe.SeriesString); ok {
+				convert[idx] = struct{}{}
+			}
+		}
+	} else {
+		for _, v := range columns {
+			switch _v := v.(type) {
+			case int:
+				if _v < 0 || _v >= len(df.Series) {
+					return nil, fmt.Errorf("column index out of range: %d", _v)
+				}
+				convert[_v] = struct{}{}
+			case string:
+				idx, err := df.NameToColumn(_v, dataframe.DontLock)
+				if err != nil {
+					return nil, err
+				}
+				convert[idx] = struct{}{}
+			default:
+				return nil, fmt.Errorf("unknown column: %v", _v)
+			}
+		}
+	}
+
+	// Names of the output Series
+	names := map[string]struct{}{}
+	for idx, s := range df.Series {
+		if _, exists := convert[idx]; !exists {
+			names[s.Name(dataframe.DontLock)] = struct{}{}
+		}
+	}
+
+	newName := func(name string) (string, error) {
+		if _, exists := names[name]; exists {
+			return "", fmt.Errorf("generated Series name is not unique: %s", name)
+		}
+		names[name] = struct{}{}
+		return name, nil
+	}
+
+	seriess := []dataframe.Series{}
+	dummies := []dataframe.Series{}
+
+	for idx, s := range df.Series {
+
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if _, exists := convert[idx]; !exists {
+			seriess = append(seriess, s.Copy())
+			continue
+		}
+
+		codes, uniques := factorize(s, true)
+
+		prefix := s.Name(dataframe.DontLock)
+		if p, exists := opts[0].Prefix[prefix]; exists {
+			prefix = p
+		}
+
+		newSeries := func(name string) dataframe.Series {
+			init := &dataframe.SeriesInit{Size: len(codes)}
+			if opts[0].Float64 {
+				return dataframe.NewSeriesFloat64(name, init)
+			}
+			return dataframe.NewSeriesInt64(name, init)
+		}
+
+		set := func(ds dataframe.Series, row int, val int) {
+			if opts[0].Float64 {
+				ds.Update(row, float64(val), dataframe.DontLock)
+			} else {
+				ds.Update(row, int64(val), dataframe.DontLock)
+			}
+		}
+
+		start := 0
+		if opts[0].DropFirst {
+			start = 1
+		}
+
+		generated := []dataframe.Series{}
+		for i := start; i < len(uniques); i++ {
+			name, err := newName(prefix + sep + s.ValueString(uniques[i], dataframe.DontLock))
+			if err != nil {
+				return nil, err
+			}
+			generated = append(generated, newSeries(name))
+		}
+
+		var nilSeries dataframe.Series
+		if opts[0].DummyNil {
+			name, err := newName(prefix + sep + "nil")
+			if err != nil {
+				return nil, err
+			}
+			nilSeries = newSeries(name)
+		}
+
+		for row, code := range codes {
+			for _, ds := range generated {
+				set(ds, row, 0)
+			}
+			if code >= start {
+				set(generated[code-start], row, 1)
+			}
+			if nilSeries != nil {
+				set(nilSeries, row, dataframe.B(code == -1))
+			}
+		}
+
+		dummies = append(dummies, generated...)
+		if nilSeries != nil {
+			dummies = append(dummies, nilSeries)
+		}
+	}
+
+	return dataframe.NewDataFrame(append(seriess, dummies...)...), nil
+}
+
+// FactorizeOptions configures Factorize.
+type FactorizeOptions struct {
+
+	// Sort can be set to true if the uniques should be sorted. Otherwise they are in order of appearance.
+	Sort bool
+
+	// Float64 can be set to true to return the codes as a SeriesFloat64 instead of a SeriesInt64.
+	// This is required to use the codes with matrix.MatrixWrap.
+	Float64 bool
+
+	// DontLock can be set to true if the Series should not be locked.
+	DontLock bool
+}
+
+// Factorize encodes the values of s as integer codes. The code of a value is the index of the value
+// in uniques, which is a Series of the same type as s. nil values are encoded as -1.
+// Values are compared using their string representation.
+//
+// See: https://pandas.pydata.org/pandas-docs/stable/reference/api/pandas.factorize.html
+func Factorize(ctx context.Context, s dataframe.Series, opts ...FactorizeOptions) (dataframe.Series, dataframe.Series, error) {
+
+	if len(opts) == 0 {
+		opts = append(opts, FactorizeOptions{})
+	}
+
+	if !opts[0].DontLock {
+		s.Lock()
+		defer s.Unlock()
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	c, u := factorize(s, opts[0].Sort)
+
+	name := s.Name(dataframe.DontLock)
+
+	var codes, uniques dataframe.Series
+
+	init := &dataframe.SeriesInit{Capacity: len(c)}
+	if opts[0].Float64 {
+		codes = dataframe.NewSeriesFloat64(name, init)
+	} else {
+		codes = dataframe.NewSeriesInt64(name, init)
+	}
+	for _, code := range c {
+		if opts[0].Float64 {
+			codes.Append(float64(code), dataframe.DontLock)
+		} else {
+			codes.Append(int64(code), dataframe.DontLock)
+		}
+	}
+
+	if ns, ok := s.(dataframe.NewSerieser); ok {
+		uniques = ns.NewSeries(name, &dataframe.SeriesInit{Capacity: len(u)})
+	} else {
+		uniques = s.Copy()
+		uniques.Reset(dataframe.DontLock)
+	}
+	for _, row := range u {
+		uniques.Append(s.Value(row, dataframe.DontLock), dataframe.DontLock)
+	}
+
+	return codes, uniques, nil
+}
+
+// factorize returns the code of each row and the row of the first occurrence of each unique value.
+// It does not lock the Series.
+func factorize(s dataframe.Series, sorted bool) ([]int, []int) {
+
+	nRows := s.NRows(dataframe.DontLock)
+
+	codes := make([]int, nRows)
+	uniques := []int{}
+	lookup := map[string]int{}
+
+	for row := 0; row < nRows; row++ {
+		if s.Value(row, dataframe.DontLock) == nil {
+			codes[row] = -1
+			continue
+		}
+
+		key := s.ValueString(row, dataframe.DontLock)
+		code, exists := lookup[key]
+		if !exists {
+			code = len(uniques)
+			lookup[key] = code
+			uniques = append(uniques, row)
+		}
+		codes[row] = code
+	}
+
+	if sorted {
+		order := make([]int, len(uniques))
+		for i := range order {
+			order[i] = i
+		}
+		sort.SliceStable(order, func(i, j int) bool {
+			return s.IsLessThanFunc(s.Value(uniques[order[i]], dataframe.DontLock), s.Value(uniques[order[j]], dataframe.DontLock))
+		})
+
+		newCode := make([]int, len(order))
+		newUniques := make([]int, len(order))
+		for i, old := range order {
+			newCode[old] = i
+			newUniques[i] = uniques[old]
+		}
+
+		for row, code := range codes {
+			if code != -1 {
+				codes[row] = newCode[code]
+			}
+		}
+		uniques = newUniques
+	}
+
+	return codes, uniques
+}
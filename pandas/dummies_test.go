@@ -0,0 +1,137 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package pandas
+
+import (
+	"testing"
+
+	dataframe "github.com/rocketlaunchr/dataframe-go"
+)
+
+func TestGetDummies(t *testing.T) {
+
+	df := dataframe.NewDataFrame(
+		dataframe.NewSeriesInt64("n", nil, 1, 2, 3, 4),
+		dataframe.NewSeriesString("c", nil, "b", "a", nil, "b"),
+	)
+
+	// Expected values are from pandas' get_dummies
+	tests := []struct {
+		columns []interface{}
+		opts    GetDummiesOptions
+		expDf   *dataframe.DataFrame
+	}{
+		{
+			nil,
+			GetDummiesOptions{},
+			dataframe.NewDataFrame(
+				dataframe.NewSeriesInt64("n", nil, 1, 2, 3, 4),
+				dataframe.NewSeriesInt64("c_a", nil, 0, 1, 0, 0),
+				dataframe.NewSeriesInt64("c_b", nil, 1, 0, 0, 1),
+			),
+		},
+		{
+			[]interface{}{"c"},
+			GetDummiesOptions{DropFirst: true, DummyNil: true},
+			dataframe.NewDataFrame(
+				dataframe.NewSeriesInt64("n", nil, 1, 2, 3, 4),
+				dataframe.NewSeriesInt64("c_b", nil, 1, 0, 0, 1),
+				dataframe.NewSeriesInt64("c_nil", nil, 0, 0, 1, 0),
+			),
+		},
+		{
+			[]interface{}{1},
+			GetDummiesOptions{Prefix: map[string]string{"c": "col"}, PrefixSep: ":", Float64: true},
+			dataframe.NewDataFrame(
+				dataframe.NewSeriesInt64("n", nil, 1, 2, 3, 4),
+				dataframe.NewSeriesFloat64("col:a", nil, 0, 1, 0, 0),
+				dataframe.NewSeriesFloat64("col:b", nil, 1, 0, 0, 1),
+			),
+		},
+	}
+
+	for i, tc := range tests {
+		out, err := GetDummies(ctx, df, tc.columns, tc.opts)
+		if err != nil {
+			t.Errorf("%d: error encountered: %v", i, err)
+			continue
+		}
+
+		if eq, err := out.IsEqual(ctx, tc.expDf, dataframe.IsEqualOptions{CheckName: true}); !eq {
+			t.Errorf("%d: wrong val: %v\n%v", i, err, out.Table())
+		}
+	}
+
+	// Generated names that are not unique
+	errTests := []struct {
+		df   *dataframe.DataFrame
+		opts GetDummiesOptions
+	}{
+		{
+			dataframe.NewDataFrame(dataframe.NewSeriesString("c", nil, "nil", nil)),
+			GetDummiesOptions{DummyNil: true},
+		},
+		{
+			dataframe.NewDataFrame(
+				dataframe.NewSeriesString("c", nil, "a", "b"),
+				dataframe.NewSeriesInt64("c_a", nil, 1, 2),
+			),
+			GetDummiesOptions{},
+		},
+	}
+
+	for i, tc := range errTests {
+		if _, err := GetDummies(ctx, tc.df, nil, tc.opts); err == nil {
+			t.Errorf("%d: expected error for non-unique name", i)
+		}
+	}
+
+	// Out of range column
+	if _, err := GetDummies(ctx, df, []interface{}{2}); err == nil {
+		t.Errorf("expected error for out of range column")
+	}
+
+	// Unknown column type
+	if _, err := GetDummies(ctx, df, []interface{}{1.5}); err == nil {
+		t.Errorf("expected error for unknown column")
+	}
+}
+
+func TestFactorize(t *testing.T) {
+
+	s := dataframe.NewSeriesString("c", nil, "b", "a", nil, "b")
+
+	// Expected values are from pandas' factorize
+	tests := []struct {
+		opts       FactorizeOptions
+		expCodes   dataframe.Series
+		expUniques dataframe.Series
+	}{
+		{
+			FactorizeOptions{},
+			dataframe.NewSeriesInt64("c", nil, 0, 1, -1, 0),
+			dataframe.NewSeriesString("c", nil, "b", "a"),
+		},
+		{
+			FactorizeOptions{Sort: true, Float64: true},
+			dataframe.NewSeriesFloat64("c", nil, 1, 0, -1, 1),
+			dataframe.NewSeriesString("c", nil, "a", "b"),
+		},
+	}
+
+	for i, tc := range tests {
+		codes, uniques, err := Factorize(ctx, s, tc.opts)
+		if err != nil {
+			t.Errorf("%d: error encountered: %v", i, err)
+			continue
+		}
+
+		if eq, _ := codes.IsEqual(ctx, tc.expCodes); !eq {
+			t.Errorf("%d: wrong codes: expected: %v actual: %v", i, tc.expCodes, codes)
+		}
+
+		if eq, _ := uniques.IsEqual(ctx, tc.expUniques); !eq {
+			t.Errorf("%d: wrong uniques: expected: %v actual: %v", i, tc.expUniques, uniques)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package dataframe
+
+import (
+	"context"
+	"sort"
+)
+
+// RankMethod sets how equal values are ranked.
+type RankMethod int
+
+const (
+	// RankAverage assigns equal values the average of their ranks.
+	RankAverage RankMethod = 0
+
+	// RankMin assigns equal values the lowest of their ranks.
+	RankMin RankMethod = 1
+
+	// RankMax assigns equal values the highest of their ranks.
+	RankMax RankMethod = 2
+
+	// RankFirst assigns equal values ranks in the order they appear.
+	RankFirst RankMethod = 3
+
+	// RankDense is like RankMin, but the rank always increases by 1 between groups of equal values.
+	RankDense RankMethod = 4
+)
+
+// NilPlacement sets how nil values are ranked.
+type NilPlacement int
+
+const (
+	// NilKeep assigns nil values a rank of nil.
+	NilKeep NilPlacement = 0
+
+	// NilTop ranks nil values before all other values.
+	NilTop NilPlacement = 1
+
+	// NilBottom ranks nil values after all other values.
+	NilBottom NilPlacement = 2
+)
+
+// RankOptions configures Rank.
+type RankOptions struct {
+
+	// Method sets how equal values are ranked. The default is RankAverage.
+	Method RankMethod
+
+	// Desc can be set to rank in descending order (i.e. the largest value is ranked 1).
+	Desc bool
+
+	// Nil sets how nil values are ranked. The default is NilKeep.
+	Nil NilPlacement
+
+	// DontLock can be set to true if the Series should not be locked.
+	DontLock bool
+}
+
+// Rank returns the (1-based) rank of each value in s. Values are compared using s's IsEqualFunc and IsLessThanFunc.
+//
+// See: https://pandas.pydata.org/pandas-docs/stable/reference/api/pandas.Series.rank.html
+func Rank(ctx context.Context, s Series, opts ...RankOptions) (*SeriesFloat64, error) {
+
+	if len(opts) == 0 {
+		opts = append(opts, RankOptions{})
+	}
+
+	if !opts[0].DontLock {
+		s.Lock()
+		defer s.Unlock()
+	}
+
+	nRows := s.NRows(dontLock)
+
+	nils := []int{}
+	rows := []int{}
+	for row := 0; row < nRows; row++ {
+		if s.Value(row, dontLock) == nil {
+			nils = append(nils, row)
+		} else {
+			rows = append(rows, row)
+		}
+	}
+
+	var err error
+	sort.SliceStable(rows, func(i, j int) bool {
+		if err == nil {
+			err = ctx.Err()
+		}
+		if err != nil {
+			return false
+		}
+
+		a, b := s.Value(rows[i], dontLock), s.Value(rows[j], dontLock)
+		if opts[0].Desc {
+			a, b = b, a
+		}
+		return !s.IsEqualFunc(a, b) && s.IsLessThanFunc(a, b)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Split the rows into groups of equal values
+	groups := [][]int{}
+
+	if opts[0].Nil == NilTop && len(nils) > 0 {
+		groups = append(groups, nils)
+	}
+
+	for i, row := range rows {
+		if i > 0 && s.IsEqualFunc(s.Value(rows[i-1], dontLock), s.Value(row, dontLock)) {
+			groups[len(groups)-1] = append(groups[len(groups)-1], row)
+			continue
+		}
+		groups = append(groups, []int{row})
+	}
+
+	if opts[0].Nil == NilBottom && len(nils) > 0 {
+		groups = append(groups, nils)
+	}
+
+	// Assign ranks
+	out := NewSeriesFloat64(s.Name(dontLock), &SeriesInit{Size: nRows})
+	for i := range out.Values {
+		out.Values[i] = nan()
+	}
+	out.nilCount = nRows
+
+	pos := 0
+	for g, group := range groups {
+		for i, row := range group {
+			var rank float64
+
+			switch opts[0].Method {
+			case RankAverage:
+				rank = float64(2*pos+len(group)-1)/2 + 1
+			case RankMin:
+				rank = float64(pos + 1)
+			case RankMax:
+				rank = float64(pos + len(group))
+			case RankFirst:
+				rank = float64(pos + i + 1)
+			case RankDense:
+				rank = float64(g + 1)
+			default:
+				panic("unknown RankMethod")
+			}
+
+			out.Values[row] = rank
+			out.nilCount--
+		}
+		pos += len(group)
+	}
+
+	return out, nil
+}
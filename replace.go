@@ -0,0 +1,247 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package dataframe
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+)
+
+// ReplaceOptions modifies the behavior of the Replace function.
+type ReplaceOptions struct {
+
+	// Regex can be set to true if the (string) keys of the replacements are regular expressions.
+	// Each match in a SeriesString is replaced with the corresponding value, which must be a string
+	// and can contain references to submatches (e.g. $1). The regular expressions are applied in sorted order.
+	// It only applies to SeriesString.
+	//
+	// See: https://golang.org/pkg/regexp/#Regexp.ReplaceAllString
+	Regex bool
+
+	// InPlace will perform the replacement on the current Series or DataFrame.
+	// If InPlace is not set, a new Series or DataFrame will be returned.
+	InPlace bool
+
+	// DontLock can be set to true if the Series or DataFrame should not be locked.
+	DontLock bool
+}
+
+// Replace replaces values in a Series or DataFrame. The keys of replacements are the old values
+// and the map values are the new values. A nil key can be used to replace nil values and a nil map value
+// can be used to set values to nil. When sdf is a DataFrame, the replacements are applied to each Series.
+// The new values must be valid for each Series that contains the old values.
+//
+// Values are compared using Go's equality operator. Numeric keys are converted to the type of each Series
+// (eg. an int key matches the equivalent float64 value of a SeriesFloat64). An error is returned (before any
+// value is modified) if a new value can't be stored in a Series.
+//
+// If the InPlace option is set, the function returns nil. Instead the Series or DataFrame is modified "in place".
+// Alternatively, a new Series or DataFrame is returned.
+//
+// See: https://pandas.pydata.org/pandas-docs/stable/reference/api/pandas.DataFrame.replace.html
+func Replace(ctx context.Context, sdf interface{}, replacements map[interface{}]interface{}, opts ...ReplaceOptions) (interface{}, error) {
+
+	if len(opts) == 0 {
+		opts = append(opts, ReplaceOptions{})
+	}
+
+	var patterns []*regexp.Regexp
+	var templates []string
+
+	regexKeys := map[interface{}]struct{}{}
+
+	if opts[0].Regex {
+		keys := []string{}
+		for k := range replacements {
+			if str, ok := k.(string); ok {
+				keys = append(keys, str)
+			}
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			re, err := regexp.Compile(k)
+			if err != nil {
+				return nil, err
+			}
+			template, ok := replacements[k].(string)
+			if !ok {
+				return nil, fmt.Errorf("replacement for regular expression %q must be a string", k)
+			}
+			patterns = append(patterns, re)
+			templates = append(templates, template)
+			regexKeys[k] = struct{}{}
+		}
+	}
+
+	// lookup returns the (non-regex) replacements with keys converted to the type of s.
+	lookup := func(s Series) map[interface{}]interface{} {
+		out := normalizeKeys(replacements, s)
+		for k := range regexKeys {
+			delete(out, k)
+		}
+		return out
+	}
+
+	// validate checks that the new values can be stored in s before anything is modified.
+	validate := func(s Series) error {
+		lookup := lookup(s)
+		checked := map[interface{}]struct{}{}
+
+		nRows := s.NRows(dontLock)
+		for row := 0; row < nRows; row++ {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			val := s.Value(row, dontLock)
+			if _, exists := checked[val]; exists {
+				continue
+			}
+
+			if newVal, exists := lookup[val]; exists {
+				if !assignable(s, newVal) {
+					return fmt.Errorf("replacement for %v is not valid for Series: %s", val, s.Name(dontLock))
+				}
+				checked[val] = struct{}{}
+			}
+		}
+		return nil
+	}
+
+	replace := func(s Series) (Series, error) {
+		if !opts[0].InPlace {
+			s = s.Copy()
+		}
+
+		lookup := lookup(s)
+		_, isString := s.(*SeriesString)
+
+		nRows := s.NRows(dontLock)
+		for row := 0; row < nRows; row++ {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+
+			val := s.Value(row, dontLock)
+
+			if newVal, exists := lookup[val]; exists {
+				s.Update(row, newVal, dontLock)
+				continue
+			}
+
+			if isString && val != nil && len(patterns) > 0 {
+				str := val.(string)
+				for i, re := range patterns {
+					str = re.ReplaceAllString(str, templates[i])
+				}
+				if str != val.(string) {
+					s.Update(row, str, dontLock)
+				}
+			}
+		}
+
+		return s, nil
+	}
+
+	switch typ := sdf.(type) {
+	case Series:
+		if !opts[0].DontLock {
+			typ.Lock()
+			defer typ.Unlock()
+		}
+
+		if err := validate(typ); err != nil {
+			return nil, err
+		}
+
+		s, err := replace(typ)
+		if err != nil || opts[0].InPlace {
+			return nil, err
+		}
+		return s, nil
+	case *DataFrame:
+		if !opts[0].DontLock {
+			typ.Lock()
+			defer typ.Unlock()
+		}
+
+		for _, s := range typ.Series {
+			if err := validate(s); err != nil {
+				return nil, err
+			}
+		}
+
+		seriess := []Series{}
+		for _, s := range typ.Series {
+			ns, err := replace(s)
+			if err != nil {
+				return nil, err
+			}
+			seriess = append(seriess, ns)
+		}
+
+		if opts[0].InPlace {
+			return nil, nil
+		}
+		return NewDataFrame(seriess...), nil
+	default:
+		panic("sdf must be a Series or DataFrame")
+	}
+}
+
+// normalizeKeys returns a copy of m where numeric keys are converted to the type of the values of s
+// (int64 for SeriesInt64 and float64 for SeriesFloat64). For other Series, int keys are converted to int64.
+func normalizeKeys(m map[interface{}]interface{}, s Series) map[interface{}]interface{} {
+
+	out := make(map[interface{}]interface{}, len(m))
+	for k, v := range m {
+		switch s.(type) {
+		case *SeriesFloat64:
+			switch _k := k.(type) {
+			case int:
+				k = float64(_k)
+			case int64:
+				k = float64(_k)
+			case float32:
+				k = float64(_k)
+			}
+		case *SeriesInt64:
+			switch _k := k.(type) {
+			case int:
+				k = int64(_k)
+			case float64:
+				if !isInf(_k, 0) && _k == math.Trunc(_k) {
+					k = int64(_k)
+				}
+			}
+		default:
+			if i, ok := k.(int); ok {
+				k = int64(i)
+			}
+		}
+		out[k] = v
+	}
+	return out
+}
+
+// assignable returns true if val can be stored in s.
+func assignable(s Series, val interface{}) (ok bool) {
+
+	ns, isNS := s.(NewSerieser)
+	if !isNS {
+		return true
+	}
+
+	defer func() {
+		if x := recover(); x != nil {
+			ok = false
+		}
+	}()
+
+	ns.NewSeries("", &SeriesInit{Capacity: 1}).Append(val, dontLock)
+	return true
+}
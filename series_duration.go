@@ -0,0 +1,930 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package dataframe
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"golang.org/x/exp/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// SeriesDuration is used for series containing time.Duration data.
+// Values are formatted using time.Duration.String.
+type SeriesDuration struct {
+	valFormatter ValueToStringFormatter
+
+	lock sync.RWMutex
+	name string
+
+	// Values is exported to better improve interoperability with various sub-packages.
+	//
+	// WARNING: Do not modify.
+	Values   []*time.Duration
+	nilCount int
+}
+
+// NewSeriesDuration creates a new series with the underlying type as time.Duration.
+func NewSeriesDuration(name string, init *SeriesInit, vals ...interface{}) *SeriesDuration {
+	s := &SeriesDuration{
+		name:     name,
+		Values:   []*time.Duration{},
+		nilCount: 0,
+	}
+
+	var (
+		size     int
+		capacity int
+	)
+
+	if init != nil {
+		size = init.Size
+		capacity = init.Capacity
+		if size > capacity {
+			capacity = size
+		}
+	}
+
+	s.Values = make([]*time.Duration, size, capacity)
+	s.valFormatter = DefaultValueFormatter
+
+	for idx, v := range vals {
+
+		// Special case
+		if idx == 0 {
+			if ds, ok := vals[0].([]time.Duration); ok {
+				for idx, v := range ds {
+					val := s.valToPointer(v)
+					if idx < size {
+						s.Values[idx] = val
+					} else {
+						s.Values = append(s.Values, val)
+					}
+				}
+				break
+			}
+		}
+
+		val := s.valToPointer(v)
+		if val == nil {
+			s.nilCount++
+		}
+
+		if idx < size {
+			s.Values[idx] = val
+		} else {
+			s.Values = append(s.Values, val)
+		}
+	}
+
+	var lVals int
+	if len(vals) > 0 {
+		if ds, ok := vals[0].([]time.Duration); ok {
+			lVals = len(ds)
+		} else {
+			lVals = len(vals)
+		}
+	}
+
+	if lVals < size {
+		s.nilCount = s.nilCount + size - lVals
+	}
+
+	return s
+}
+
+// NewSeries creates a new initialized SeriesDuration.
+func (s *SeriesDuration) NewSeries(name string, init *SeriesInit) Series {
+	return NewSeriesDuration(name, init)
+}
+
+// Name returns the series name.
+func (s *SeriesDuration) Name(opts ...Options) string {
+	if len(opts) == 0 || !opts[0].DontLock {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	return s.name
+}
+
+// Rename renames the series.
+func (s *SeriesDuration) Rename(n string, opts ...Options) {
+	if len(opts) == 0 || !opts[0].DontLock {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	s.name = n
+}
+
+// Type returns the type of data the series holds.
+func (s *SeriesDuration) Type() string {
+	return "duration"
+}
+
+// NRows returns how many rows the series contains.
+func (s *SeriesDuration) NRows(opts ...Options) int {
+	if len(opts) == 0 || !opts[0].DontLock {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	return len(s.Values)
+}
+
+// Value returns the value of a particular row.
+// The return value could be nil or the concrete type
+// the data type held by the series.
+// Pointers are never returned.
+func (s *SeriesDuration) Value(row int, opts ...Options) interface{} {
+	if len(opts) == 0 || !opts[0].DontLock {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	val := s.Values[row]
+	if val == nil {
+		return nil
+	}
+	return *val
+}
+
+// ValueString returns a string representation of a
+// particular row. The string representation is defined
+// by the function set in SetValueToStringFormatter.
+// By default, a nil value is returned as "NaN".
+func (s *SeriesDuration) ValueString(row int, opts ...Options) string {
+	return s.valFormatter(s.Value(row, opts...))
+}
+
+// Prepend is used to set a value to the beginning of the
+// series. val can be a concrete data type or nil. Nil
+// represents the absence of a value.
+func (s *SeriesDuration) Prepend(val interface{}, opts ...Options) {
+	if len(opts) == 0 || !opts[0].DontLock {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	// See: https://stackoverflow.com/questions/41914386/what-is-the-mechanism-of-using-append-to-prepend-in-go
+
+	if cap(s.Values) > len(s.Values) {
+		// There is already extra capacity so copy current values by 1 spot
+		s.Values = s.Values[:len(s.Values)+1]
+		copy(s.Values[1:], s.Values)
+		s.Values[0] = s.valToPointer(val)
+		if s.Values[0] == nil {
+			s.nilCount++
+		}
+		return
+	}
+
+	// No room, new slice needs to be allocated:
+	s.insert(0, val)
+}
+
+// Append is used to set a value to the end of the series.
+// val can be a concrete data type or nil. Nil represents
+// the absence of a value.
+func (s *SeriesDuration) Append(val interface{}, opts ...Options) int {
+	var locked bool
+	if len(opts) == 0 || !opts[0].DontLock {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		locked = true
+	}
+
+	row := s.NRows(Options{DontLock: locked})
+	s.insert(row, val)
+	return row
+}
+
+// Insert is used to set a value at an arbitrary row in
+// the series. All existing values from that row onwards
+// are shifted by 1. val can be a concrete data type or nil.
+// Nil represents the absence of a value.
+func (s *SeriesDuration) Insert(row int, val interface{}, opts ...Options) {
+	if len(opts) == 0 || !opts[0].DontLock {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	s.insert(row, val)
+}
+
+func (s *SeriesDuration) insert(row int, val interface{}) {
+
+	switch V := val.(type) {
+	case []time.Duration:
+		var vals []*time.Duration
+		for _, v := range V {
+			v := v
+			vals = append(vals, &v)
+		}
+		s.Values = append(s.Values[:row], append(vals, s.Values[row:]...)...)
+		return
+	case []*time.Duration:
+		for _, v := range V {
+			if v == nil {
+				s.nilCount++
+			}
+		}
+		s.Values = append(s.Values[:row], append(V, s.Values[row:]...)...)
+		return
+	}
+
+	s.Values = append(s.Values, nil)
+	copy(s.Values[row+1:], s.Values[row:])
+
+	v := s.valToPointer(val)
+	if v == nil {
+		s.nilCount++
+	}
+
+	s.Values[row] = v
+}
+
+// Remove is used to delete the value of a particular row.
+func (s *SeriesDuration) Remove(row int, opts ...Options) {
+	if len(opts) == 0 || !opts[0].DontLock {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	if s.Values[row] == nil {
+		s.nilCount--
+	}
+
+	s.Values = append(s.Values[:row], s.Values[row+1:]...)
+}
+
+// Reset is used clear all data contained in the Series.
+func (s *SeriesDuration) Reset(opts ...Options) {
+	if len(opts) == 0 || !opts[0].DontLock {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	s.Values = []*time.Duration{}
+	s.nilCount = 0
+}
+
+// Update is used to update the value of a particular row.
+// val can be a concrete data type or nil. Nil represents
+// the absence of a value.
+func (s *SeriesDuration) Update(row int, val interface{}, opts ...Options) {
+	if len(opts) == 0 || !opts[0].DontLock {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	newVal := s.valToPointer(val)
+
+	if s.Values[row] == nil && newVal != nil {
+		s.nilCount--
+	} else if s.Values[row] != nil && newVal == nil {
+		s.nilCount++
+	}
+
+	s.Values[row] = newVal
+}
+
+// ValuesIterator will return an iterator that can be used to iterate through all the values.
+func (s *SeriesDuration) ValuesIterator(opts ...ValuesOptions) func() (*int, interface{}, int) {
+
+	var (
+		row  int
+		step int = 1
+	)
+
+	var dontReadLock bool
+
+	if len(opts) > 0 {
+		dontReadLock = opts[0].DontReadLock
+
+		row = opts[0].InitialRow
+		step = opts[0].Step
+		if step == 0 {
+			panic("Step can not be zero")
+		}
+	}
+
+	return func() (*int, interface{}, int) {
+		// Should this be on the outside?
+		if !dontReadLock {
+			s.lock.RLock()
+			defer s.lock.RUnlock()
+		}
+
+		if row > len(s.Values)-1 || row < 0 {
+			// Don't iterate further
+			return nil, nil, 0
+		}
+
+		val := s.Values[row]
+		var out interface{}
+		if val == nil {
+			out = nil
+		} else {
+			out = *val
+		}
+		row = row + step
+		return &[]int{row - step}[0], out, len(s.Values)
+	}
+}
+
+// valToPointer converts v to a *time.Duration. Strings are parsed using time.ParseDuration.
+func (s *SeriesDuration) valToPointer(v interface{}) *time.Duration {
+	switch val := v.(type) {
+	case nil:
+		return nil
+	case *time.Duration:
+		if val == nil {
+			return nil
+		}
+		return &[]time.Duration{*val}[0]
+	case time.Duration:
+		return &val
+	case *string:
+		if val == nil {
+			return nil
+		}
+		d, err := time.ParseDuration(*val)
+		if err != nil {
+			_ = v.(time.Duration) // Intentionally panic
+		}
+		return &d
+	case string:
+		d, err := time.ParseDuration(val)
+		if err != nil {
+			_ = v.(time.Duration) // Intentionally panic
+		}
+		return &d
+	default:
+		_ = v.(time.Duration) // Intentionally panic
+		return nil
+	}
+}
+
+// SetValueToStringFormatter is used to set a function
+// to convert the value of a particular row to a string
+// representation.
+func (s *SeriesDuration) SetValueToStringFormatter(f ValueToStringFormatter) {
+	if f == nil {
+		s.valFormatter = DefaultValueFormatter
+		return
+	}
+	s.valFormatter = f
+}
+
+// Swap is used to swap 2 values based on their row position.
+func (s *SeriesDuration) Swap(row1, row2 int, opts ...Options) {
+	if row1 == row2 {
+		return
+	}
+
+	if len(opts) == 0 || !opts[0].DontLock {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+
+	s.Values[row1], s.Values[row2] = s.Values[row2], s.Values[row1]
+}
+
+// IsEqualFunc returns true if a is equal to b.
+func (s *SeriesDuration) IsEqualFunc(a, b interface{}) bool {
+
+	if a == nil {
+		if b == nil {
+			return true
+		}
+		return false
+	}
+
+	if b == nil {
+		return false
+	}
+	d1 := a.(time.Duration)
+	d2 := b.(time.Duration)
+
+	return d1 == d2
+}
+
+// IsLessThanFunc returns true if a is less than b.
+func (s *SeriesDuration) IsLessThanFunc(a, b interface{}) bool {
+
+	if a == nil {
+		if b == nil {
+			return true
+		}
+		return true
+	}
+
+	if b == nil {
+		return false
+	}
+	d1 := a.(time.Duration)
+	d2 := b.(time.Duration)
+
+	return d1 < d2
+}
+
+// Sort will sort the series.
+// It will return true if sorting was completed or false when the context is canceled.
+func (s *SeriesDuration) Sort(ctx context.Context, opts ...SortOptions) (completed bool) {
+
+	defer func() {
+		if x := recover(); x != nil {
+			completed = false
+		}
+	}()
+
+	if len(opts) == 0 {
+		opts = append(opts, SortOptions{})
+	}
+
+	if !opts[0].DontLock {
+		s.Lock()
+		defer s.Unlock()
+	}
+
+	sortFunc := func(i, j int) (ret bool) {
+		if err := ctx.Err(); err != nil {
+			panic(err)
+		}
+
+		defer func() {
+			if opts[0].Desc {
+				ret = !ret
+			}
+		}()
+
+		if s.Values[i] == nil {
+			if s.Values[j] == nil {
+				// both are nil
+				return true
+			}
+			return true
+		}
+
+		if s.Values[j] == nil {
+			// i has value and j is nil
+			return false
+		}
+		// Both are not nil
+		return *s.Values[i] < *s.Values[j]
+	}
+
+	if opts[0].Stable {
+		sort.SliceStable(s.Values, sortFunc)
+	} else {
+		sort.Slice(s.Values, sortFunc)
+	}
+
+	return true
+}
+
+// Lock will lock the Series allowing you to directly manipulate
+// the underlying slice with confidence.
+func (s *SeriesDuration) Lock() {
+	s.lock.Lock()
+}
+
+// Unlock will unlock the Series that was previously locked.
+func (s *SeriesDuration) Unlock() {
+	s.lock.Unlock()
+}
+
+// Copy will create a new copy of the series.
+// It is recommended that you lock the Series before attempting
+// to Copy.
+func (s *SeriesDuration) Copy(r ...Range) Series {
+
+	if len(s.Values) == 0 {
+		return &SeriesDuration{
+			valFormatter: s.valFormatter,
+			name:         s.name,
+			Values:       []*time.Duration{},
+			nilCount:     s.nilCount,
+		}
+	}
+
+	if len(r) == 0 {
+		r = append(r, Range{})
+	}
+
+	start, end, err := r[0].Limits(len(s.Values))
+	if err != nil {
+		panic(err)
+	}
+
+	// Copy slice
+	x := s.Values[start : end+1]
+	newSlice := append(x[:0:0], x...)
+
+	var nilCount int
+	for _, v := range newSlice {
+		if v == nil {
+			nilCount++
+		}
+	}
+
+	return &SeriesDuration{
+		valFormatter: s.valFormatter,
+		name:         s.name,
+		Values:       newSlice,
+		nilCount:     nilCount,
+	}
+}
+
+// Table will produce the Series in a table.
+func (s *SeriesDuration) Table(opts ...TableOptions) string {
+
+	if len(opts) == 0 {
+		opts = append(opts, TableOptions{R: &Range{}})
+	} else if opts[0].R == nil {
+		opts[0].R = &Range{}
+	}
+
+	if !opts[0].DontLock {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	display := TableDisplayOptions(opts...)
+
+	data := [][]string{}
+
+	headers := []string{"", display.Truncate(s.name)} // row header is blank
+	footers := []string{fmt.Sprintf("%dx%d", len(s.Values), 1), s.Type()}
+
+	if len(s.Values) > 0 {
+
+		start, end, err := opts[0].R.Limits(len(s.Values))
+		if err != nil {
+			panic(err)
+		}
+
+		for _, row := range display.Rows(start, end) {
+			if row == -1 {
+				data = append(data, []string{"⋮", "⋮"})
+				continue
+			}
+			sVals := []string{fmt.Sprintf("%d:", row), display.ValueString(s, row)}
+			data = append(data, sVals)
+		}
+
+	}
+
+	var buf bytes.Buffer
+
+	table := tablewriter.NewWriter(&buf)
+	table.SetHeader(headers)
+	for _, v := range data {
+		table.Append(v)
+	}
+	table.SetFooter(footers)
+	table.SetAlignment(tablewriter.ALIGN_CENTER)
+
+	table.Render()
+
+	return buf.String()
+}
+
+// String implements the fmt.Stringer interface. It does not lock the Series.
+func (s *SeriesDuration) String() string {
+
+	display := StringDisplayOptions()
+
+	out := "[ "
+	for _, row := range display.Rows(0, len(s.Values)-1) {
+		if row == -1 {
+			out = out + "... "
+			continue
+		}
+		out = out + display.ValueString(s, row) + " "
+	}
+	return out + "]"
+}
+
+// ContainsNil will return whether or not the series contains any nil values.
+func (s *SeriesDuration) ContainsNil(opts ...Options) bool {
+	if len(opts) == 0 || !opts[0].DontLock {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	return s.nilCount > 0
+}
+
+// NilCount will return how many nil values are in the series.
+func (s *SeriesDuration) NilCount(opts ...NilCountOptions) (int, error) {
+	if len(opts) == 0 {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+		return s.nilCount, nil
+	}
+
+	if !opts[0].DontLock {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	var (
+		ctx context.Context
+		r   *Range
+	)
+
+	if opts[0].Ctx == nil {
+		ctx = context.Background()
+	} else {
+		ctx = opts[0].Ctx
+	}
+
+	if opts[0].R == nil {
+		r = &Range{}
+	} else {
+		r = opts[0].R
+	}
+
+	start, end, err := r.Limits(len(s.Values))
+	if err != nil {
+		return 0, err
+	}
+
+	if start == 0 && end == len(s.Values)-1 {
+		return s.nilCount, nil
+	}
+
+	var nilCount int
+
+	for i := start; i <= end; i++ {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+
+		if s.Values[i] == nil {
+
+			if opts[0].StopAtOneNil {
+				return 1, nil
+			}
+
+			nilCount++
+		}
+	}
+
+	return nilCount, nil
+}
+
+// ToSeriesInt64 will convert the Series to a SeriesInt64. The unit is nanoseconds.
+// The operation does not lock the Series.
+func (s *SeriesDuration) ToSeriesInt64(ctx context.Context, removeNil bool, conv ...func(interface{}) (*int64, error)) (*SeriesInt64, error) {
+
+	ec := NewErrorCollection()
+
+	ss := NewSeriesInt64(s.name, &SeriesInit{Capacity: s.NRows(dontLock)})
+
+	for row, rowVal := range s.Values {
+
+		// Cancel operation
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if rowVal == nil {
+			if removeNil {
+				continue
+			}
+			ss.values = append(ss.values, nil)
+			ss.nilCount++
+		} else {
+			if len(conv) == 0 {
+				cv := int64(*rowVal)
+				ss.values = append(ss.values, &cv)
+			} else {
+				cv, err := conv[0](rowVal)
+				if err != nil {
+					// interpret as nil
+					ss.values = append(ss.values, nil)
+					ss.nilCount++
+					ec.AddError(&RowError{Row: row, Err: err}, false)
+				} else {
+					if cv == nil {
+						ss.values = append(ss.values, nil)
+						ss.nilCount++
+					} else {
+						ss.values = append(ss.values, cv)
+					}
+				}
+			}
+		}
+	}
+
+	if !ec.IsNil(false) {
+		return ss, ec
+	}
+
+	return ss, nil
+}
+
+// ToSeriesFloat64 will convert the Series to a SeriesFloat64. The unit is seconds.
+// The operation does not lock the Series.
+//
+// See: https://godoc.org/github.com/rocketlaunchr/dataframe-go#SeriesDuration.ToUnits for other units.
+func (s *SeriesDuration) ToSeriesFloat64(ctx context.Context, removeNil bool, conv ...func(interface{}) (float64, error)) (*SeriesFloat64, error) {
+
+	ec := NewErrorCollection()
+
+	ss := NewSeriesFloat64(s.name, &SeriesInit{Capacity: s.NRows(dontLock)})
+
+	for row, rowVal := range s.Values {
+
+		// Cancel operation
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if rowVal == nil {
+			if removeNil {
+				continue
+			}
+			ss.Values = append(ss.Values, nan())
+			ss.nilCount++
+		} else {
+			if len(conv) == 0 {
+				cv := (*rowVal).Seconds()
+				ss.Values = append(ss.Values, cv)
+			} else {
+				cv, err := conv[0](rowVal)
+				if err != nil {
+					// interpret as nil
+					ss.Values = append(ss.Values, nan())
+					ss.nilCount++
+					ec.AddError(&RowError{Row: row, Err: err}, false)
+				} else {
+					if isNaN(cv) {
+						ss.nilCount++
+					}
+					ss.Values = append(ss.Values, cv)
+				}
+			}
+		}
+	}
+
+	if !ec.IsNil(false) {
+		return ss, ec
+	}
+
+	return ss, nil
+}
+
+// ToSeriesMixed will convert the Series to a SeriesMIxed.
+// The operation does not lock the Series.
+func (s *SeriesDuration) ToSeriesMixed(ctx context.Context, removeNil bool, conv ...func(interface{}) (interface{}, error)) (*SeriesMixed, error) {
+	ec := NewErrorCollection()
+
+	ss := NewSeriesMixed(s.name, &SeriesInit{Capacity: s.NRows(dontLock)})
+
+	for row, rowVal := range s.Values {
+
+		// Cancel operation
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if rowVal == nil {
+			if removeNil {
+				continue
+			}
+			ss.values = append(ss.values, nil)
+			ss.nilCount++
+		} else {
+			if len(conv) == 0 {
+				cv := *rowVal
+				ss.values = append(ss.values, cv)
+			} else {
+				cv, err := conv[0](rowVal)
+				if err != nil {
+					// interpret as nil
+					ss.values = append(ss.values, nil)
+					ss.nilCount++
+					ec.AddError(&RowError{Row: row, Err: err}, false)
+				} else {
+					if cv == nil {
+						ss.nilCount++
+					}
+					ss.values = append(ss.values, cv)
+				}
+			}
+		}
+	}
+
+	if !ec.IsNil(false) {
+		return ss, ec
+	}
+
+	return ss, nil
+}
+
+// ToUnits will convert the Series to a SeriesFloat64, where each value is expressed in unit.
+// eg. Use time.Hour to convert the values to hours.
+// The operation does not lock the Series.
+func (s *SeriesDuration) ToUnits(ctx context.Context, unit time.Duration) (*SeriesFloat64, error) {
+
+	if unit <= 0 {
+		panic("unit must be positive")
+	}
+
+	return s.ToSeriesFloat64(ctx, false, func(v interface{}) (float64, error) {
+		d := *v.(*time.Duration)
+		return float64(d/unit) + float64(d%unit)/float64(unit), nil
+	})
+}
+
+// FillRand will fill a Series with random data. probNil is a value between between 0 and 1 which
+// determines if a row is given a nil value. The random values are interpreted as seconds.
+func (s *SeriesDuration) FillRand(src rand.Source, probNil float64, rander Rander, opts ...FillRandOptions) {
+
+	rng := rand.New(src)
+
+	capacity := cap(s.Values)
+	length := len(s.Values)
+	s.nilCount = 0
+
+	for i := 0; i < length; i++ {
+		if rng.Float64() < probNil {
+			// nil
+			s.Values[i] = nil
+			s.nilCount++
+		} else {
+			s.Values[i] = &[]time.Duration{time.Duration(rander.Rand() * float64(time.Second))}[0]
+		}
+	}
+
+	if capacity > length {
+		excess := capacity - length
+		for i := 0; i < excess; i++ {
+			if rng.Float64() < probNil {
+				// nil
+				s.Values = append(s.Values, nil)
+				s.nilCount++
+			} else {
+				s.Values = append(s.Values, &[]time.Duration{time.Duration(rander.Rand() * float64(time.Second))}[0])
+			}
+		}
+	}
+}
+
+// IsEqual returns true if s2's values are equal to s.
+func (s *SeriesDuration) IsEqual(ctx context.Context, s2 Series, opts ...IsEqualOptions) (bool, error) {
+	if len(opts) == 0 || !opts[0].DontLock {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	// Check type
+	ds, ok := s2.(*SeriesDuration)
+	if !ok {
+		return false, nil
+	}
+
+	// Check number of values
+	if len(s.Values) != len(ds.Values) {
+		return false, nil
+	}
+
+	// Check name
+	if len(opts) != 0 && opts[0].CheckName {
+		if s.name != ds.name {
+			return false, nil
+		}
+	}
+
+	// Check values
+	for i, v := range s.Values {
+		if err := ctx.Err(); err != nil {
+			return false, err
+		}
+
+		if v == nil {
+			if ds.Values[i] == nil {
+				// Both are nil
+				continue
+			} else {
+				return false, nil
+			}
+		}
+
+		if ds.Values[i] == nil || *v != *ds.Values[i] {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
@@ -557,6 +557,8 @@ func (s *SeriesInt64) Table(opts ...TableOptions) string {
 
 	if len(opts) == 0 {
 		opts = append(opts, TableOptions{R: &Range{}})
+	} else if opts[0].R == nil {
+		opts[0].R = &Range{}
 	}
 
 	if !opts[0].DontLock {
@@ -564,9 +566,11 @@ func (s *SeriesInt64) Table(opts ...TableOptions) string {
 		defer s.lock.RUnlock()
 	}
 
+	display := TableDisplayOptions(opts...)
+
 	data := [][]string{}
 
-	headers := []string{"", s.name} // row header is blank
+	headers := []string{"", display.Truncate(s.name)} // row header is blank
 	footers := []string{fmt.Sprintf("%dx%d", len(s.values), 1), s.Type()}
 
 	if len(s.values) > 0 {
@@ -576,8 +580,12 @@ func (s *SeriesInt64) Table(opts ...TableOptions) string {
 			panic(err)
 		}
 
-		for row := start; row <= end; row++ {
-			sVals := []string{fmt.Sprintf("%d:", row), s.ValueString(row, dontLock)}
+		for _, row := range display.Rows(start, end) {
+			if row == -1 {
+				data = append(data, []string{"⋮", "⋮"})
+				continue
+			}
+			sVals := []string{fmt.Sprintf("%d:", row), display.ValueString(s, row)}
 			data = append(data, sVals)
 		}
 
@@ -601,23 +609,15 @@ func (s *SeriesInt64) Table(opts ...TableOptions) string {
 // String implements the fmt.Stringer interface. It does not lock the Series.
 func (s *SeriesInt64) String() string {
 
-	count := len(s.values)
+	display := StringDisplayOptions()
 
 	out := "[ "
-
-	if count > 6 {
-		idx := []int{0, 1, 2, count - 3, count - 2, count - 1}
-		for j, row := range idx {
-			if j == 3 {
-				out = out + "... "
-			}
-			out = out + s.ValueString(row, dontLock) + " "
+	for _, row := range display.Rows(0, len(s.values)-1) {
+		if row == -1 {
+			out = out + "... "
+			continue
 		}
-		return out + "]"
-	}
-
-	for row := range s.values {
-		out = out + s.ValueString(row, dontLock) + " "
+		out = out + display.ValueString(s, row) + " "
 	}
 	return out + "]"
 }
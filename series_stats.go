@@ -4,6 +4,7 @@ package dataframe
 
 import (
 	"context"
+	"time"
 )
 
 // Mean returns the mean. All non-nil values are ignored.
@@ -111,3 +112,70 @@ func (s *SeriesInt64) Sum(ctx context.Context) (float64, error) {
 
 	return float64(sum), nil
 }
+
+// Mean returns the mean. All nil values are ignored. If all values are nil, ErrNoRows is returned.
+func (s *SeriesDuration) Mean(ctx context.Context) (time.Duration, error) {
+
+	sum, err := s.Sum(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	count := len(s.Values) - s.nilCount
+	if count == 0 {
+		return 0, ErrNoRows
+	}
+
+	return sum / time.Duration(count), nil
+}
+
+// Sum returns the sum of all non-nil values. If all values are nil, 0 is returned.
+func (s *SeriesDuration) Sum(ctx context.Context) (time.Duration, error) {
+
+	var sum time.Duration
+
+	for _, v := range s.Values {
+
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+
+		if v != nil {
+			sum = sum + *v
+		}
+	}
+
+	return sum, nil
+}
+
+// Min returns the smallest non-nil value. If all values are nil, ErrNoRows is returned.
+func (s *SeriesDuration) Min(ctx context.Context) (time.Duration, error) {
+	return s.extreme(ctx, func(a, b time.Duration) bool { return a < b })
+}
+
+// Max returns the largest non-nil value. If all values are nil, ErrNoRows is returned.
+func (s *SeriesDuration) Max(ctx context.Context) (time.Duration, error) {
+	return s.extreme(ctx, func(a, b time.Duration) bool { return a > b })
+}
+
+func (s *SeriesDuration) extreme(ctx context.Context, better func(a, b time.Duration) bool) (time.Duration, error) {
+
+	var out *time.Duration
+
+	for _, v := range s.Values {
+
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+
+		if v != nil && (out == nil || better(*v, *out)) {
+			out = v
+		}
+	}
+
+	if out == nil {
+		return 0, ErrNoRows
+	}
+
+	return *out, nil
+}
@@ -0,0 +1,475 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package dataframe
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// StringAccessor provides vectorized string functions for a SeriesString.
+// Each function returns a new Series (or DataFrame). nil values remain nil.
+//
+// See: https://pandas.pydata.org/pandas-docs/stable/reference/series.html#string-handling
+type StringAccessor struct {
+	s        *SeriesString
+	dontLock bool
+}
+
+// Str returns a StringAccessor for the Series. If the DontLock option is set,
+// the Series is not locked by the string functions.
+func (s *SeriesString) Str(opts ...Options) StringAccessor {
+	return StringAccessor{s: s, dontLock: len(opts) > 0 && opts[0].DontLock}
+}
+
+// PadSide sets which side of a string is padded.
+type PadSide int
+
+const (
+	// PadLeft pads the left side of a string.
+	PadLeft PadSide = 0
+
+	// PadRight pads the right side of a string.
+	PadRight PadSide = 1
+
+	// PadBoth pads both sides of a string. When an odd number of characters are required,
+	// the extra character is added to the right side.
+	PadBoth PadSide = 2
+)
+
+// Lower converts all characters to lower case.
+func (a StringAccessor) Lower(ctx context.Context) (*SeriesString, error) {
+	return a.apply(ctx, strings.ToLower)
+}
+
+// Upper converts all characters to upper case.
+func (a StringAccessor) Upper(ctx context.Context) (*SeriesString, error) {
+	return a.apply(ctx, strings.ToUpper)
+}
+
+// Title converts the first character of each word to upper case and the remaining characters to lower case.
+func (a StringAccessor) Title(ctx context.Context) (*SeriesString, error) {
+	return a.apply(ctx, func(str string) string {
+		return strings.Title(strings.ToLower(str))
+	})
+}
+
+// Trim removes all leading and trailing characters contained in cutset.
+// If cutset is empty, white space is removed.
+func (a StringAccessor) Trim(ctx context.Context, cutset string) (*SeriesString, error) {
+	return a.apply(ctx, func(str string) string {
+		if cutset == "" {
+			return strings.TrimSpace(str)
+		}
+		return strings.Trim(str, cutset)
+	})
+}
+
+// TrimLeft removes all leading characters contained in cutset.
+// If cutset is empty, white space is removed.
+func (a StringAccessor) TrimLeft(ctx context.Context, cutset string) (*SeriesString, error) {
+	return a.apply(ctx, func(str string) string {
+		if cutset == "" {
+			return strings.TrimLeftFunc(str, unicode.IsSpace)
+		}
+		return strings.TrimLeft(str, cutset)
+	})
+}
+
+// TrimRight removes all trailing characters contained in cutset.
+// If cutset is empty, white space is removed.
+func (a StringAccessor) TrimRight(ctx context.Context, cutset string) (*SeriesString, error) {
+	return a.apply(ctx, func(str string) string {
+		if cutset == "" {
+			return strings.TrimRightFunc(str, unicode.IsSpace)
+		}
+		return strings.TrimRight(str, cutset)
+	})
+}
+
+// Contains returns whether each value contains pattern. If regex is set, pattern is a regular expression.
+// The returned Series contains 1 (true) or 0 (false) and is displayed using BoolValueFormatter.
+func (a StringAccessor) Contains(ctx context.Context, pattern string, regex bool) (*SeriesInt64, error) {
+	if regex {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, err
+		}
+		return a.applyBool(ctx, re.MatchString)
+	}
+	return a.applyBool(ctx, func(str string) bool {
+		return strings.Contains(str, pattern)
+	})
+}
+
+// StartsWith returns whether each value starts with pattern. If regex is set, pattern is a regular expression.
+// The returned Series contains 1 (true) or 0 (false) and is displayed using BoolValueFormatter.
+func (a StringAccessor) StartsWith(ctx context.Context, pattern string, regex bool) (*SeriesInt64, error) {
+	if regex {
+		re, err := regexp.Compile(`^(?:` + pattern + `)`)
+		if err != nil {
+			return nil, err
+		}
+		return a.applyBool(ctx, re.MatchString)
+	}
+	return a.applyBool(ctx, func(str string) bool {
+		return strings.HasPrefix(str, pattern)
+	})
+}
+
+// EndsWith returns whether each value ends with pattern. If regex is set, pattern is a regular expression.
+// The returned Series contains 1 (true) or 0 (false) and is displayed using BoolValueFormatter.
+func (a StringAccessor) EndsWith(ctx context.Context, pattern string, regex bool) (*SeriesInt64, error) {
+	if regex {
+		re, err := regexp.Compile(`(?:` + pattern + `)$`)
+		if err != nil {
+			return nil, err
+		}
+		return a.applyBool(ctx, re.MatchString)
+	}
+	return a.applyBool(ctx, func(str string) bool {
+		return strings.HasSuffix(str, pattern)
+	})
+}
+
+// Replace replaces all occurrences of old with new. If regex is set, old is a regular expression
+// and new can contain references to submatches (e.g. $1).
+//
+// See: https://golang.org/pkg/regexp/#Regexp.ReplaceAllString
+func (a StringAccessor) Replace(ctx context.Context, old, new string, regex bool) (*SeriesString, error) {
+	if regex {
+		re, err := regexp.Compile(old)
+		if err != nil {
+			return nil, err
+		}
+		return a.apply(ctx, func(str string) string {
+			return re.ReplaceAllString(str, new)
+		})
+	}
+	return a.apply(ctx, func(str string) string {
+		return strings.Replace(str, old, new, -1)
+	})
+}
+
+// Len returns the number of characters (runes) of each value.
+func (a StringAccessor) Len(ctx context.Context) (*SeriesInt64, error) {
+
+	if !a.dontLock {
+		a.s.lock.Lock()
+		defer a.s.lock.Unlock()
+	}
+
+	out := NewSeriesInt64(a.s.name, &SeriesInit{Capacity: len(a.s.values)})
+	for _, val := range a.s.values {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if val == nil {
+			out.Append(nil, dontLock)
+		} else {
+			out.Append(utf8.RuneCountInString(*val), dontLock)
+		}
+	}
+	return out, nil
+}
+
+// Pad pads each value with fillchar so that it contains at least width characters (runes).
+func (a StringAccessor) Pad(ctx context.Context, width int, side PadSide, fillchar rune) (*SeriesString, error) {
+	return a.apply(ctx, func(str string) string {
+		n := width - utf8.RuneCountInString(str)
+		if n <= 0 {
+			return str
+		}
+
+		switch side {
+		case PadLeft:
+			return strings.Repeat(string(fillchar), n) + str
+		case PadRight:
+			return str + strings.Repeat(string(fillchar), n)
+		case PadBoth:
+			left := n / 2
+			return strings.Repeat(string(fillchar), left) + str + strings.Repeat(string(fillchar), n-left)
+		default:
+			panic("unknown PadSide")
+		}
+	})
+}
+
+// Slice returns the characters (runes) of each value from start (inclusive) to end (exclusive).
+// Negative positions are counted from the end of the string. If end is nil, the characters up to the
+// end of the string are returned. Positions beyond the string are clamped.
+func (a StringAccessor) Slice(ctx context.Context, start int, end *int) (*SeriesString, error) {
+	return a.apply(ctx, func(str string) string {
+		runes := []rune(str)
+		n := len(runes)
+
+		clamp := func(pos int) int {
+			if pos < 0 {
+				pos += n
+			}
+			if pos < 0 {
+				return 0
+			}
+			if pos > n {
+				return n
+			}
+			return pos
+		}
+
+		s, e := clamp(start), n
+		if end != nil {
+			e = clamp(*end)
+		}
+		if s >= e {
+			return ""
+		}
+		return string(runes[s:e])
+	})
+}
+
+// Cat concatenates each value with the corresponding values of others, separated by sep.
+// If any of the values is nil, the result is nil. others must contain the same number of rows
+// and are not locked.
+func (a StringAccessor) Cat(ctx context.Context, sep string, others ...*SeriesString) (*SeriesString, error) {
+
+	if !a.dontLock {
+		a.s.lock.Lock()
+		defer a.s.lock.Unlock()
+	}
+
+	nRows := len(a.s.values)
+	for _, o := range others {
+		if len(o.values) != nRows {
+			return nil, fmt.Errorf("Series %q must contain %d rows", o.name, nRows)
+		}
+	}
+
+	out := NewSeriesString(a.s.name, &SeriesInit{Capacity: nRows})
+
+	parts := make([]string, 0, len(others)+1)
+ROWS:
+	for row, val := range a.s.values {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if val == nil {
+			out.Append(nil, dontLock)
+			continue
+		}
+
+		parts = append(parts[:0], *val)
+		for _, o := range others {
+			if o.values[row] == nil {
+				out.Append(nil, dontLock)
+				continue ROWS
+			}
+			parts = append(parts, *o.values[row])
+		}
+		out.Append(strings.Join(parts, sep), dontLock)
+	}
+	return out, nil
+}
+
+// Split splits each value into substrings separated by sep and returns them as a DataFrame.
+// The ith Series contains the ith substring and is named "<name>_i". Values with fewer substrings are padded with nil.
+// At least one Series ("<name>_0") is always returned.
+// If sep is empty, values are split around white space. n sets the maximum number of substrings.
+// If n is not positive, all substrings are returned.
+func (a StringAccessor) Split(ctx context.Context, sep string, n int) (*DataFrame, error) {
+
+	if !a.dontLock {
+		a.s.lock.Lock()
+		defer a.s.lock.Unlock()
+	}
+
+	split := make([][]string, len(a.s.values))
+	nCols := 1 // At least one Series is returned (even if every value is nil)
+
+	for row, val := range a.s.values {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if val == nil {
+			continue
+		}
+
+		var parts []string
+		if sep == "" {
+			parts = strings.Fields(*val)
+			if n > 0 && len(parts) > n {
+				// Keep the remainder (without leading white space) in the last substring
+				rem := strings.TrimLeftFunc(*val, unicode.IsSpace)
+				for i := 0; i < n-1; i++ {
+					rem = strings.TrimLeftFunc(strings.TrimPrefix(rem, parts[i]), unicode.IsSpace)
+				}
+				parts = append(parts[:n-1], rem)
+			}
+		} else if n > 0 {
+			parts = strings.SplitN(*val, sep, n)
+		} else {
+			parts = strings.Split(*val, sep)
+		}
+
+		split[row] = parts
+		if len(parts) > nCols {
+			nCols = len(parts)
+		}
+	}
+
+	names := make([]string, nCols)
+	for i := range names {
+		names[i] = fmt.Sprintf("%s_%d", a.s.name, i)
+	}
+
+	return newStringDataFrame(names, split), nil
+}
+
+// Extract returns the capture groups of the first match of the regular expression pattern as a DataFrame.
+// Each capture group is placed in a separate Series, which is named after the capture group if it is named.
+// Otherwise it is named "<name>_i", where i is the index of the capture group (starting at 0).
+// If a value does not match (or a capture group does not participate in the match), the result is nil.
+// An error is returned if the Series names are not unique (eg. a capture group named "<name>_1").
+func (a StringAccessor) Extract(ctx context.Context, pattern string) (*DataFrame, error) {
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	if re.NumSubexp() == 0 {
+		return nil, fmt.Errorf("pattern must contain at least one capture group")
+	}
+
+	if !a.dontLock {
+		a.s.lock.Lock()
+		defer a.s.lock.Unlock()
+	}
+
+	names := []string{}
+	seen := map[string]struct{}{}
+	for i, name := range re.SubexpNames()[1:] {
+		if name == "" {
+			name = fmt.Sprintf("%s_%d", a.s.name, i)
+		}
+		if _, exists := seen[name]; exists {
+			return nil, fmt.Errorf("generated Series name is not unique: %s", name)
+		}
+		seen[name] = struct{}{}
+		names = append(names, name)
+	}
+
+	extracted := make([][]*string, len(a.s.values))
+
+	for row, val := range a.s.values {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if val == nil {
+			continue
+		}
+
+		loc := re.FindStringSubmatchIndex(*val)
+		if loc == nil {
+			continue
+		}
+
+		groups := make([]*string, len(names))
+		for i := range groups {
+			start, end := loc[2*(i+1)], loc[2*(i+1)+1]
+			if start >= 0 {
+				group := (*val)[start:end]
+				groups[i] = &group
+			}
+		}
+		extracted[row] = groups
+	}
+
+	seriess := []Series{}
+	for i, name := range names {
+		s := NewSeriesString(name, &SeriesInit{Capacity: len(extracted)})
+		for _, groups := range extracted {
+			if groups == nil || groups[i] == nil {
+				s.Append(nil, dontLock)
+			} else {
+				s.Append(*groups[i], dontLock)
+			}
+		}
+		seriess = append(seriess, s)
+	}
+
+	return NewDataFrame(seriess...), nil
+}
+
+func (a StringAccessor) apply(ctx context.Context, fn func(string) string) (*SeriesString, error) {
+
+	if !a.dontLock {
+		a.s.lock.Lock()
+		defer a.s.lock.Unlock()
+	}
+
+	out := NewSeriesString(a.s.name, &SeriesInit{Capacity: len(a.s.values)})
+	for _, val := range a.s.values {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if val == nil {
+			out.Append(nil, dontLock)
+		} else {
+			out.Append(fn(*val), dontLock)
+		}
+	}
+	return out, nil
+}
+
+func (a StringAccessor) applyBool(ctx context.Context, fn func(string) bool) (*SeriesInt64, error) {
+
+	if !a.dontLock {
+		a.s.lock.Lock()
+		defer a.s.lock.Unlock()
+	}
+
+	out := NewSeriesInt64(a.s.name, &SeriesInit{Capacity: len(a.s.values)})
+	out.SetValueToStringFormatter(BoolValueFormatter)
+
+	for _, val := range a.s.values {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if val == nil {
+			out.Append(nil, dontLock)
+		} else {
+			out.Append(B(fn(*val)), dontLock)
+		}
+	}
+	return out, nil
+}
+
+// newStringDataFrame returns a DataFrame containing a SeriesString for each name.
+// The ith Series contains the ith value of each row (or nil).
+func newStringDataFrame(names []string, rows [][]string) *DataFrame {
+
+	seriess := []Series{}
+	for i, name := range names {
+		s := NewSeriesString(name, &SeriesInit{Capacity: len(rows)})
+		for _, vals := range rows {
+			if i < len(vals) {
+				s.Append(vals[i], dontLock)
+			} else {
+				s.Append(nil, dontLock)
+			}
+		}
+		seriess = append(seriess, s)
+	}
+
+	return NewDataFrame(seriess...)
+}
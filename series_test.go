@@ -561,3 +561,251 @@ func TestStopAtOneNil(t *testing.T) {
 		}
 	}
 }
+
+func TestRank(t *testing.T) {
+	ctx := context.Background()
+
+	s := NewSeriesInt64("score", nil, 10, 20, nil, 20, 5, 10)
+
+	tests := []struct {
+		opts     RankOptions
+		expected *SeriesFloat64
+	}{
+		{RankOptions{Method: RankAverage}, NewSeriesFloat64("score", nil, 2.5, 4.5, nil, 4.5, 1, 2.5)},
+		{RankOptions{Method: RankMin}, NewSeriesFloat64("score", nil, 2, 4, nil, 4, 1, 2)},
+		{RankOptions{Method: RankMax}, NewSeriesFloat64("score", nil, 3, 5, nil, 5, 1, 3)},
+		{RankOptions{Method: RankFirst}, NewSeriesFloat64("score", nil, 2, 4, nil, 5, 1, 3)},
+		{RankOptions{Method: RankDense}, NewSeriesFloat64("score", nil, 2, 3, nil, 3, 1, 2)},
+		{RankOptions{Method: RankMin, Desc: true, Nil: NilTop}, NewSeriesFloat64("score", nil, 4, 2, 1, 2, 6, 4)},
+		{RankOptions{Method: RankAverage, Desc: true, Nil: NilBottom}, NewSeriesFloat64("score", nil, 3.5, 1.5, 6, 1.5, 5, 3.5)},
+	}
+
+	for i, tc := range tests {
+		actual, err := Rank(ctx, s, tc.opts)
+		if err != nil {
+			t.Errorf("error encountered: %s\n", err)
+			continue
+		}
+
+		nilCount, _ := actual.NilCount()
+		expectedNilCount, _ := tc.expected.NilCount()
+
+		if !cmp.Equal(actual.Values, tc.expected.Values, cmpopts.EquateNaNs()) || nilCount != expectedNilCount {
+			t.Errorf("%d: wrong val: expected: %v actual: %v", i, tc.expected, actual)
+		}
+	}
+}
+
+func TestSeriesStringStr(t *testing.T) {
+	ctx := context.Background()
+
+	s := NewSeriesString("name", nil, "  john SMITH ", nil, "Mary-Jane doe", "Élodie")
+
+	vals := func(s Series, err error) []interface{} {
+		if err != nil {
+			t.Fatalf("error encountered: %s\n", err)
+		}
+		out := []interface{}{}
+		for row := 0; row < s.NRows(); row++ {
+			out = append(out, s.Value(row))
+		}
+		return out
+	}
+
+	tests := []struct {
+		actual   interface{}
+		expected interface{}
+	}{
+		{vals(s.Str().Lower(ctx)), []interface{}{"  john smith ", nil, "mary-jane doe", "élodie"}},
+		{vals(s.Str().Upper(ctx)), []interface{}{"  JOHN SMITH ", nil, "MARY-JANE DOE", "ÉLODIE"}},
+		{vals(s.Str().Title(ctx)), []interface{}{"  John Smith ", nil, "Mary-Jane Doe", "Élodie"}},
+		{vals(s.Str().Trim(ctx, "")), []interface{}{"john SMITH", nil, "Mary-Jane doe", "Élodie"}},
+		{vals(s.Str().TrimLeft(ctx, "")), []interface{}{"john SMITH ", nil, "Mary-Jane doe", "Élodie"}},
+		{vals(s.Str().TrimRight(ctx, "e")), []interface{}{"  john SMITH ", nil, "Mary-Jane do", "Élodi"}},
+		{vals(s.Str().Contains(ctx, "o", false)), []interface{}{int64(1), nil, int64(1), int64(1)}},
+		{vals(s.Str().Contains(ctx, "(?i)smith|doe", true)), []interface{}{int64(1), nil, int64(1), int64(0)}},
+		{vals(s.Str().StartsWith(ctx, "M", false)), []interface{}{int64(0), nil, int64(1), int64(0)}},
+		{vals(s.Str().EndsWith(ctx, "[a-z]", true)), []interface{}{int64(0), nil, int64(1), int64(1)}},
+		{vals(s.Str().Replace(ctx, `(\w+)-(\w+)`, "$2-$1", true)), []interface{}{"  john SMITH ", nil, "Jane-Mary doe", "Élodie"}},
+		{vals(s.Str().Len(ctx)), []interface{}{int64(13), nil, int64(13), int64(6)}},
+		{vals(s.Str().Pad(ctx, 8, PadBoth, '*')), []interface{}{"  john SMITH ", nil, "Mary-Jane doe", "*Élodie*"}},
+		{vals(s.Str().Slice(ctx, 0, &[]int{3}[0])), []interface{}{"  j", nil, "Mar", "Élo"}},
+		{vals(s.Str().Slice(ctx, -3, nil)), []interface{}{"TH ", nil, "doe", "die"}},
+		{vals(s.Str().Cat(ctx, "/", NewSeriesString("x", nil, "a", "b", nil, "d"))), []interface{}{"  john SMITH /a", nil, nil, "Élodie/d"}},
+	}
+
+	for i, tc := range tests {
+		if !cmp.Equal(tc.actual, tc.expected) {
+			t.Errorf("%d: wrong val: expected: %v actual: %v", i, tc.expected, tc.actual)
+		}
+	}
+
+	// Split
+	df, err := s.Str().Split(ctx, "", 2)
+	if err != nil {
+		t.Fatalf("error encountered: %s\n", err)
+	}
+
+	expected := NewDataFrame(
+		NewSeriesString("name_0", nil, "john", nil, "Mary-Jane", "Élodie"),
+		NewSeriesString("name_1", nil, "SMITH ", nil, "doe", nil),
+	)
+	if !cmp.Equal(df, expected, cmpopts.IgnoreUnexported(DataFrame{}, SeriesString{})) {
+		t.Errorf("wrong val: expected: %v actual: %v", expected, df)
+	}
+
+	// Extract
+	df, err = s.Str().Extract(ctx, `(?P<first>\w+)[ -](\w+)?`)
+	if err != nil {
+		t.Fatalf("error encountered: %s\n", err)
+	}
+
+	expected = NewDataFrame(
+		NewSeriesString("first", nil, "john", nil, "Mary", nil),
+		NewSeriesString("name_1", nil, "SMITH", nil, "Jane", nil),
+	)
+	if !cmp.Equal(df, expected, cmpopts.IgnoreUnexported(DataFrame{}, SeriesString{})) {
+		t.Errorf("wrong val: expected: %v actual: %v", expected, df)
+	}
+
+	for _, pattern := range []string{`(?P<g>\w)(?P<g>\w)`, `(\w)(?P<name_0>\w)`} {
+		if _, err := s.Str().Extract(ctx, pattern); err == nil {
+			t.Errorf("expected error for non-unique name: %s", pattern)
+		}
+	}
+
+	// Split with no values
+	for _, nils := range []*SeriesString{NewSeriesString("name", nil, nil, nil), NewSeriesString("name", nil)} {
+		df, err = nils.Str().Split(ctx, " ", 0)
+		if err != nil {
+			t.Fatalf("error encountered: %s\n", err)
+		}
+
+		expected = NewDataFrame(NewSeriesString("name_0", &SeriesInit{Size: nils.NRows()}))
+		if eq, err := df.IsEqual(ctx, expected, IsEqualOptions{CheckName: true}); !eq {
+			t.Errorf("wrong val: %v\n%v", err, df.Table())
+		}
+	}
+}
+
+func TestSeriesTimeDt(t *testing.T) {
+	ctx := context.Background()
+
+	t1 := time.Date(2020, time.December, 31, 23, 45, 0, 0, time.UTC) // Thursday
+	t2 := time.Date(2021, time.January, 4, 8, 15, 0, 0, time.UTC)    // Monday
+	s := NewSeriesTime("time", nil, t1, nil, t2)
+
+	vals := func(s Series, err error) []interface{} {
+		if err != nil {
+			t.Fatalf("error encountered: %s\n", err)
+		}
+		out := []interface{}{}
+		for row := 0; row < s.NRows(); row++ {
+			out = append(out, s.Value(row))
+		}
+		return out
+	}
+
+	tests := []struct {
+		actual   []interface{}
+		expected []interface{}
+	}{
+		{vals(s.Dt().Year(ctx)), []interface{}{int64(2020), nil, int64(2021)}},
+		{vals(s.Dt().Month(ctx)), []interface{}{int64(12), nil, int64(1)}},
+		{vals(s.Dt().Day(ctx)), []interface{}{int64(31), nil, int64(4)}},
+		{vals(s.Dt().Hour(ctx)), []interface{}{int64(23), nil, int64(8)}},
+		{vals(s.Dt().Minute(ctx)), []interface{}{int64(45), nil, int64(15)}},
+		{vals(s.Dt().Weekday(ctx)), []interface{}{int64(4), nil, int64(1)}},
+		{vals(s.Dt().DayOfYear(ctx)), []interface{}{int64(366), nil, int64(4)}},
+		{vals(s.Dt().ISOWeek(ctx)), []interface{}{int64(53), nil, int64(1)}},
+		{vals(s.Dt().Quarter(ctx)), []interface{}{int64(4), nil, int64(1)}},
+		{vals(s.Dt().Diff(ctx, NewSeriesTime("start", nil, t1, t1, t1), time.Hour)), []interface{}{0.0, nil, 80.5}},
+	}
+
+	for i, tc := range tests {
+		if !cmp.Equal(tc.actual, tc.expected) {
+			t.Errorf("%d: wrong val: expected: %v actual: %v", i, tc.expected, tc.actual)
+		}
+	}
+
+	// Time zones
+	loc := time.FixedZone("UTC+10", 10*60*60)
+
+	converted, err := s.Dt().In(ctx, loc)
+	if err != nil {
+		t.Fatalf("error encountered: %s\n", err)
+	}
+	if !converted.Values[0].Equal(t1) || converted.Values[0].Hour() != 9 || converted.Values[1] != nil {
+		t.Errorf("wrong val: expected: %v actual: %v", t1.In(loc), converted.Values[0])
+	}
+
+	localized, err := s.Dt().Localize(ctx, loc)
+	if err != nil {
+		t.Fatalf("error encountered: %s\n", err)
+	}
+	if !localized.Values[0].Equal(t1.Add(-10*time.Hour)) || localized.Values[0].Hour() != 23 {
+		t.Errorf("wrong val: expected: %v actual: %v", t1.Add(-10*time.Hour).In(loc), localized.Values[0])
+	}
+}
+
+func TestSeriesDuration(t *testing.T) {
+	ctx := context.Background()
+
+	s := NewSeriesDuration("duration", nil, "1h30m", nil, 45*time.Minute, "-15m")
+
+	if str := s.String(); str != "[ 1h30m0s NaN 45m0s -15m0s ]" {
+		t.Errorf("wrong val: expected: %v actual: %v", "[ 1h30m0s NaN 45m0s -15m0s ]", str)
+	}
+
+	// Aggregation
+	sum, _ := s.Sum(ctx)
+	mean, _ := s.Mean(ctx)
+	min, _ := s.Min(ctx)
+	max, _ := s.Max(ctx)
+
+	expected := []time.Duration{2 * time.Hour, 40 * time.Minute, -15 * time.Minute, 90 * time.Minute}
+	for i, actual := range []time.Duration{sum, mean, min, max} {
+		if actual != expected[i] {
+			t.Errorf("%d: wrong val: expected: %v actual: %v", i, expected[i], actual)
+		}
+	}
+
+	if _, err := NewSeriesDuration("empty", nil, nil).Mean(ctx); err != ErrNoRows {
+		t.Errorf("expected ErrNoRows: %v", err)
+	}
+
+	// Conversion
+	hours, err := s.ToUnits(ctx, time.Hour)
+	if err != nil {
+		t.Fatalf("error encountered: %s\n", err)
+	}
+
+	expectedHours := NewSeriesFloat64("duration", nil, 1.5, nil, 0.75, -0.25)
+	if !cmp.Equal(hours, expectedHours, cmpopts.EquateNaNs(), cmpopts.IgnoreUnexported(SeriesFloat64{})) {
+		t.Errorf("wrong val: expected: %v actual: %v", expectedHours, hours)
+	}
+
+	// Arithmetic with SeriesTime
+	t1 := time.Date(2020, time.January, 1, 9, 0, 0, 0, time.UTC)
+	start := NewSeriesTime("start", nil, t1, t1, t1, nil)
+
+	end, err := start.Dt().Add(ctx, s)
+	if err != nil {
+		t.Fatalf("error encountered: %s\n", err)
+	}
+
+	expectedEnd := NewSeriesTime("start", nil, t1.Add(90*time.Minute), nil, t1.Add(45*time.Minute), nil)
+	if eq, _ := end.IsEqual(ctx, expectedEnd); !eq {
+		t.Errorf("wrong val: expected: %v actual: %v", expectedEnd, end)
+	}
+
+	diff, err := end.Dt().Sub(ctx, start)
+	if err != nil {
+		t.Fatalf("error encountered: %s\n", err)
+	}
+
+	expectedDiff := NewSeriesDuration("start", nil, 90*time.Minute, nil, 45*time.Minute, nil)
+	if eq, _ := diff.IsEqual(ctx, expectedDiff); !eq {
+		t.Errorf("wrong val: expected: %v actual: %v", expectedDiff, diff)
+	}
+}
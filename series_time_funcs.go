@@ -0,0 +1,241 @@
+// Copyright 2018-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package dataframe
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// DateTimeAccessor provides vectorized time functions for a SeriesTime.
+// Each function returns a new Series. nil values remain nil.
+//
+// See: https://godoc.org/github.com/rocketlaunchr/dataframe-go/utils/utime for rounding and period arithmetic.
+type DateTimeAccessor struct {
+	s        *SeriesTime
+	dontLock bool
+}
+
+// Dt returns a DateTimeAccessor for the Series. If the DontLock option is set,
+// the Series is not locked by the time functions.
+func (s *SeriesTime) Dt(opts ...Options) DateTimeAccessor {
+	return DateTimeAccessor{s: s, dontLock: len(opts) > 0 && opts[0].DontLock}
+}
+
+// Year returns the year of each value.
+func (a DateTimeAccessor) Year(ctx context.Context) (*SeriesInt64, error) {
+	return a.component(ctx, func(t time.Time) int { return t.Year() })
+}
+
+// Month returns the month (1 to 12) of each value.
+func (a DateTimeAccessor) Month(ctx context.Context) (*SeriesInt64, error) {
+	return a.component(ctx, func(t time.Time) int { return int(t.Month()) })
+}
+
+// Day returns the day of the month of each value.
+func (a DateTimeAccessor) Day(ctx context.Context) (*SeriesInt64, error) {
+	return a.component(ctx, func(t time.Time) int { return t.Day() })
+}
+
+// Hour returns the hour (0 to 23) of each value.
+func (a DateTimeAccessor) Hour(ctx context.Context) (*SeriesInt64, error) {
+	return a.component(ctx, func(t time.Time) int { return t.Hour() })
+}
+
+// Minute returns the minute (0 to 59) of each value.
+func (a DateTimeAccessor) Minute(ctx context.Context) (*SeriesInt64, error) {
+	return a.component(ctx, func(t time.Time) int { return t.Minute() })
+}
+
+// Weekday returns the day of the week of each value, where Sunday is 0.
+//
+// See: https://golang.org/pkg/time/#Weekday
+func (a DateTimeAccessor) Weekday(ctx context.Context) (*SeriesInt64, error) {
+	return a.component(ctx, func(t time.Time) int { return int(t.Weekday()) })
+}
+
+// DayOfYear returns the day of the year (1 to 366) of each value.
+func (a DateTimeAccessor) DayOfYear(ctx context.Context) (*SeriesInt64, error) {
+	return a.component(ctx, func(t time.Time) int { return t.YearDay() })
+}
+
+// ISOWeek returns the ISO 8601 week number (1 to 53) of each value.
+func (a DateTimeAccessor) ISOWeek(ctx context.Context) (*SeriesInt64, error) {
+	return a.component(ctx, func(t time.Time) int {
+		_, week := t.ISOWeek()
+		return week
+	})
+}
+
+// Quarter returns the quarter (1 to 4) of each value.
+func (a DateTimeAccessor) Quarter(ctx context.Context) (*SeriesInt64, error) {
+	return a.component(ctx, func(t time.Time) int { return (int(t.Month())-1)/3 + 1 })
+}
+
+// Diff returns the difference between each value and the corresponding value of other (i.e. s - other),
+// expressed in unit. If either value is nil, the result is nil. other must contain the same number of rows
+// and is not locked. See Sub to obtain the differences as a SeriesDuration.
+//
+// Example:
+//
+//  hours, _ := end.Dt().Diff(ctx, start, time.Hour)
+//
+func (a DateTimeAccessor) Diff(ctx context.Context, other *SeriesTime, unit time.Duration) (*SeriesFloat64, error) {
+
+	if unit <= 0 {
+		panic("unit must be positive")
+	}
+
+	if !a.dontLock {
+		a.s.lock.Lock()
+		defer a.s.lock.Unlock()
+	}
+
+	nRows := len(a.s.Values)
+	if len(other.Values) != nRows {
+		return nil, fmt.Errorf("Series %q must contain %d rows", other.name, nRows)
+	}
+
+	out := NewSeriesFloat64(a.s.name, &SeriesInit{Capacity: nRows})
+	for row, t := range a.s.Values {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		o := other.Values[row]
+		if t == nil || o == nil {
+			out.Append(nil, dontLock)
+			continue
+		}
+
+		d := t.Sub(*o)
+		out.Append(float64(d/unit)+float64(d%unit)/float64(unit), dontLock)
+	}
+	return out, nil
+}
+
+// Sub returns the difference between each value and the corresponding value of other (i.e. s - other).
+// If either value is nil, the result is nil. other must contain the same number of rows and is not locked.
+func (a DateTimeAccessor) Sub(ctx context.Context, other *SeriesTime) (*SeriesDuration, error) {
+
+	if !a.dontLock {
+		a.s.lock.Lock()
+		defer a.s.lock.Unlock()
+	}
+
+	nRows := len(a.s.Values)
+	if len(other.Values) != nRows {
+		return nil, fmt.Errorf("Series %q must contain %d rows", other.name, nRows)
+	}
+
+	out := NewSeriesDuration(a.s.name, &SeriesInit{Capacity: nRows})
+	for row, t := range a.s.Values {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		o := other.Values[row]
+		if t == nil || o == nil {
+			out.Append(nil, dontLock)
+			continue
+		}
+		out.Append(t.Sub(*o), dontLock)
+	}
+	return out, nil
+}
+
+// Add adds the corresponding value of d to each value. If either value is nil, the result is nil.
+// d must contain the same number of rows and is not locked.
+func (a DateTimeAccessor) Add(ctx context.Context, d *SeriesDuration) (*SeriesTime, error) {
+
+	if !a.dontLock {
+		a.s.lock.Lock()
+		defer a.s.lock.Unlock()
+	}
+
+	nRows := len(a.s.Values)
+	if len(d.Values) != nRows {
+		return nil, fmt.Errorf("Series %q must contain %d rows", d.name, nRows)
+	}
+
+	out := NewSeriesTime(a.s.name, &SeriesInit{Capacity: nRows})
+	for row, t := range a.s.Values {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		dur := d.Values[row]
+		if t == nil || dur == nil {
+			out.Append(nil, dontLock)
+			continue
+		}
+		out.Append(t.Add(*dur), dontLock)
+	}
+	return out, nil
+}
+
+// In converts each value to the time zone loc. The instant in time is unchanged.
+//
+// See: https://golang.org/pkg/time/#Time.In
+func (a DateTimeAccessor) In(ctx context.Context, loc *time.Location) (*SeriesTime, error) {
+	return a.apply(ctx, func(t time.Time) time.Time { return t.In(loc) })
+}
+
+// Localize interprets the wall clock of each value as a time in the time zone loc.
+// The instant in time is changed (unless the offsets are equal).
+//
+// Example:
+//
+//  // 2020-01-01 09:00 UTC becomes 2020-01-01 09:00 AEDT
+//  local, _ := ts.Dt().Localize(ctx, sydney)
+//
+func (a DateTimeAccessor) Localize(ctx context.Context, loc *time.Location) (*SeriesTime, error) {
+	return a.apply(ctx, func(t time.Time) time.Time {
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)
+	})
+}
+
+func (a DateTimeAccessor) component(ctx context.Context, fn func(time.Time) int) (*SeriesInt64, error) {
+
+	if !a.dontLock {
+		a.s.lock.Lock()
+		defer a.s.lock.Unlock()
+	}
+
+	out := NewSeriesInt64(a.s.name, &SeriesInit{Capacity: len(a.s.Values)})
+	for _, t := range a.s.Values {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if t == nil {
+			out.Append(nil, dontLock)
+		} else {
+			out.Append(fn(*t), dontLock)
+		}
+	}
+	return out, nil
+}
+
+func (a DateTimeAccessor) apply(ctx context.Context, fn func(time.Time) time.Time) (*SeriesTime, error) {
+
+	if !a.dontLock {
+		a.s.lock.Lock()
+		defer a.s.lock.Unlock()
+	}
+
+	out := NewSeriesTime(a.s.name, &SeriesInit{Capacity: len(a.s.Values)})
+	for _, t := range a.s.Values {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if t == nil {
+			out.Append(nil, dontLock)
+		} else {
+			out.Append(fn(*t), dontLock)
+		}
+	}
+	return out, nil
+}
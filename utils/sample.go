@@ -0,0 +1,241 @@
+// Copyright 2019-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package utils
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"sort"
+
+	dataframe "github.com/rocketlaunchr/dataframe-go"
+)
+
+// SampleOptions modifies the behavior of Sample.
+type SampleOptions struct {
+
+	// Replace can be set to true if rows can be selected more than once.
+	Replace bool
+
+	// Weights sets the probability of each row being selected. It must contain a non-negative number
+	// for each row. nil values are treated as 0. The weights do not need to sum to 1.
+	// When nil, all rows have the same probability. Weights is not locked.
+	Weights dataframe.Series
+
+	// Src is the source of randomness. It can be set to produce reproducible samples.
+	// When nil, the global source of the math/rand package is used.
+	Src rand.Source
+
+	// PreserveOrder can be set to true if the selected rows should retain their original order.
+	PreserveOrder bool
+
+	// DontLock can be set to true if the Series or DataFrame should not be locked.
+	DontLock bool
+}
+
+// Sample randomly selects rows from a Series or DataFrame and returns them as a new Series or DataFrame.
+// n can be an int, representing the number of rows, or a float64, representing the fraction of rows.
+//
+// NOTE: All Series must implement the NewSerieser interface.
+//
+// See: https://pandas.pydata.org/pandas-docs/stable/reference/api/pandas.DataFrame.sample.html
+func Sample(ctx context.Context, sdf interface{}, n interface{}, opts ...SampleOptions) (interface{}, error) {
+
+	if len(opts) == 0 {
+		opts = append(opts, SampleOptions{})
+	}
+
+	if !opts[0].DontLock {
+		defer lockSDF(sdf)()
+	}
+
+	nRows := nRowsSDF(sdf)
+
+	var k int
+	switch _n := n.(type) {
+	case int:
+		k = _n
+	case float64:
+		k = int(math.Round(_n * float64(nRows)))
+	default:
+		panic("n must be an int or float64")
+	}
+
+	if k < 0 {
+		return nil, errors.New("n must not be negative")
+	}
+
+	if nRows == 0 && k > 0 {
+		return nil, dataframe.ErrNoRows
+	}
+
+	if !opts[0].Replace && k > nRows {
+		return nil, errors.New("n must not be larger than the number of rows when Replace is false")
+	}
+
+	rnd := newRand(opts[0].Src)
+
+	var rows []int
+
+	if opts[0].Weights == nil {
+		if opts[0].Replace {
+			for i := 0; i < k; i++ {
+				rows = append(rows, rnd.Intn(nRows))
+			}
+		} else {
+			rows = rnd.Perm(nRows)[:k]
+		}
+	} else {
+		weights, err := sampleWeights(opts[0].Weights, nRows)
+		if err != nil {
+			return nil, err
+		}
+
+		if opts[0].Replace {
+			rows, err = weightedWithReplacement(ctx, rnd, weights, k)
+		} else {
+			rows, err = weightedWithoutReplacement(ctx, rnd, weights, k)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if opts[0].PreserveOrder {
+		sort.Ints(rows)
+	}
+
+	return dataframe.SelectRows(sdf, rows, dataframe.DontLock), nil
+}
+
+// lockSDF locks a Series or DataFrame and returns a function that unlocks it.
+func lockSDF(sdf interface{}) func() {
+	switch typ := sdf.(type) {
+	case dataframe.Series:
+		typ.Lock()
+		return typ.Unlock
+	case *dataframe.DataFrame:
+		typ.Lock()
+		return func() { typ.Unlock() }
+	default:
+		panic("sdf must be a Series or DataFrame")
+	}
+}
+
+// nRowsSDF returns the number of rows of a Series or DataFrame. It does not lock sdf.
+func nRowsSDF(sdf interface{}) int {
+	switch typ := sdf.(type) {
+	case dataframe.Series:
+		return typ.NRows(dataframe.DontLock)
+	case *dataframe.DataFrame:
+		return typ.NRows(dataframe.DontLock)
+	default:
+		panic("sdf must be a Series or DataFrame")
+	}
+}
+
+// newRand returns a random number generator based on src.
+// When src is nil, the global source of the math/rand package is used.
+func newRand(src rand.Source) *rand.Rand {
+	if src == nil {
+		return rand.New(rand.NewSource(rand.Int63()))
+	}
+	return rand.New(src)
+}
+
+// sampleWeights returns the weights as float64.
+func sampleWeights(s dataframe.Series, nRows int) ([]float64, error) {
+
+	if s.NRows(dataframe.DontLock) != nRows {
+		return nil, errors.New("weights must contain a value for each row")
+	}
+
+	weights := make([]float64, nRows)
+	for row := range weights {
+		var w float64
+
+		switch v := s.Value(row, dataframe.DontLock).(type) {
+		case nil:
+		case float64:
+			w = v
+		case int64:
+			w = float64(v)
+		default:
+			return nil, errors.New("weights must be a SeriesFloat64 or SeriesInt64")
+		}
+
+		if w < 0 || math.IsInf(w, 0) {
+			return nil, errors.New("weights must be non-negative and finite")
+		}
+		weights[row] = w
+	}
+
+	return weights, nil
+}
+
+func weightedWithReplacement(ctx context.Context, rnd *rand.Rand, weights []float64, k int) ([]int, error) {
+
+	cumulative := make([]float64, len(weights))
+	var total float64
+	for i, w := range weights {
+		total += w
+		cumulative[i] = total
+	}
+
+	if total == 0 && k > 0 {
+		return nil, errors.New("weights must not all be 0")
+	}
+
+	rows := []int{}
+	for i := 0; i < k; i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		x := rnd.Float64() * total
+		row := sort.Search(len(cumulative), func(i int) bool { return cumulative[i] > x })
+		if row == len(cumulative) {
+			row--
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// weightedWithoutReplacement uses the algorithm by Efraimidis and Spirakis.
+//
+// See: https://doi.org/10.1016/j.ipl.2005.11.003
+func weightedWithoutReplacement(ctx context.Context, rnd *rand.Rand, weights []float64, k int) ([]int, error) {
+
+	type item struct {
+		row int
+		key float64
+	}
+
+	items := []item{}
+	for row, w := range weights {
+		if w == 0 {
+			continue
+		}
+		items = append(items, item{row, math.Pow(rnd.Float64(), 1/w)})
+	}
+
+	if k > len(items) {
+		return nil, errors.New("n must not be larger than the number of rows with a non-zero weight")
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(items, func(i, j int) bool { return items[i].key > items[j].key })
+
+	rows := []int{}
+	for _, it := range items[:k] {
+		rows = append(rows, it.row)
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,113 @@
+// Copyright 2019-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package utils
+
+import (
+	"context"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	dataframe "github.com/rocketlaunchr/dataframe-go"
+)
+
+var ctx = context.Background()
+
+// int64s returns the values of a SeriesInt64 (nil values are returned as -1).
+func int64s(s dataframe.Series) []int64 {
+	out := []int64{}
+	for row := 0; row < s.NRows(); row++ {
+		if v := s.Value(row); v != nil {
+			out = append(out, v.(int64))
+		} else {
+			out = append(out, -1)
+		}
+	}
+	return out
+}
+
+func TestSample(t *testing.T) {
+
+	vals := []interface{}{}
+	for i := 0; i < 20; i++ {
+		vals = append(vals, int64(i))
+	}
+	df := dataframe.NewDataFrame(
+		dataframe.NewSeriesInt64("a", nil, vals...),
+		dataframe.NewSeriesString("b", &dataframe.SeriesInit{Size: 20}),
+	)
+
+	// Without replacement
+	out, err := Sample(ctx, df, 0.5, SampleOptions{Src: rand.NewSource(1), PreserveOrder: true})
+	if err != nil {
+		t.Fatalf("error encountered: %v", err)
+	}
+
+	sampled := int64s(out.(*dataframe.DataFrame).Series[0])
+	if len(sampled) != 10 {
+		t.Fatalf("wrong number of rows: expected: %d actual: %d", 10, len(sampled))
+	}
+	for i := 1; i < len(sampled); i++ {
+		if sampled[i] <= sampled[i-1] {
+			t.Errorf("rows not unique or not in order: %v", sampled)
+			break
+		}
+	}
+
+	// The same seed produces the same sample
+	out2, _ := Sample(ctx, df, 0.5, SampleOptions{Src: rand.NewSource(1), PreserveOrder: true})
+	if actual := int64s(out2.(*dataframe.DataFrame).Series[0]); !reflect.DeepEqual(actual, sampled) {
+		t.Errorf("sample not reproducible: expected: %v actual: %v", sampled, actual)
+	}
+
+	// With replacement
+	out, err = Sample(ctx, df.Series[0], 100, SampleOptions{Src: rand.NewSource(2), Replace: true})
+	if err != nil {
+		t.Fatalf("error encountered: %v", err)
+	}
+	if n := out.(dataframe.Series).NRows(); n != 100 {
+		t.Errorf("wrong number of rows: expected: %d actual: %d", 100, n)
+	}
+
+	if _, err := Sample(ctx, df, 21); err == nil {
+		t.Errorf("expected error when n is larger than the number of rows")
+	}
+}
+
+func TestSampleWeights(t *testing.T) {
+
+	s := dataframe.NewSeriesInt64("a", nil, 0, 1, 2, 3, 4)
+	weights := dataframe.NewSeriesFloat64("w", nil, 0, 1, nil, 1000, 1)
+
+	// Rows with a weight of 0 (or nil) are never selected
+	for seed := int64(0); seed < 20; seed++ {
+		out, err := Sample(ctx, s, 3, SampleOptions{Src: rand.NewSource(seed), Weights: weights, PreserveOrder: true})
+		if err != nil {
+			t.Fatalf("error encountered: %v", err)
+		}
+
+		expected := []int64{1, 3, 4}
+		if actual := int64s(out.(dataframe.Series)); !reflect.DeepEqual(actual, expected) {
+			t.Errorf("%d: wrong sample: expected: %v actual: %v", seed, expected, actual)
+		}
+	}
+
+	if _, err := Sample(ctx, s, 4, SampleOptions{Weights: weights}); err == nil {
+		t.Errorf("expected error when n is larger than the number of rows with a non-zero weight")
+	}
+
+	// With replacement, rows are selected in proportion to their weight
+	out, err := Sample(ctx, s, 1000, SampleOptions{Src: rand.NewSource(1), Weights: weights, Replace: true})
+	if err != nil {
+		t.Fatalf("error encountered: %v", err)
+	}
+
+	counts := map[int64]int{}
+	for _, v := range int64s(out.(dataframe.Series)) {
+		counts[v]++
+	}
+
+	if counts[0] != 0 || counts[2] != 0 || counts[3] < 990 {
+		t.Errorf("wrong distribution: %v", counts)
+	}
+}
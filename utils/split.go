@@ -0,0 +1,267 @@
+// Copyright 2019-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package utils
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"sort"
+
+	dataframe "github.com/rocketlaunchr/dataframe-go"
+)
+
+// TrainTestSplitOptions modifies the behavior of TrainTestSplit.
+type TrainTestSplitOptions struct {
+
+	// DontShuffle can be set to true if the rows should not be shuffled before splitting.
+	DontShuffle bool
+
+	// Stratify can be set to split the rows such that each set contains approximately the same proportion
+	// of each value (class) of Stratify. It must contain a value for each row. nil is treated as a distinct class.
+	// Stratify is not locked.
+	Stratify dataframe.Series
+
+	// Src is the source of randomness. It can be set to produce reproducible splits.
+	// When nil, the global source of the math/rand package is used.
+	Src rand.Source
+
+	// PreserveOrder can be set to true if the rows in each set should retain their original order.
+	PreserveOrder bool
+
+	// DontLock can be set to true if the Series or DataFrame should not be locked.
+	DontLock bool
+}
+
+// TrainTestSplit randomly splits the rows of a Series or DataFrame into a training set and a test set.
+// testSize is the fraction of rows (between 0 and 1) that are placed in the test set.
+// The sets are returned as new Series or DataFrames.
+//
+// NOTE: All Series must implement the NewSerieser interface.
+//
+// See: https://scikit-learn.org/stable/modules/generated/sklearn.model_selection.train_test_split.html
+func TrainTestSplit(ctx context.Context, sdf interface{}, testSize float64, opts ...TrainTestSplitOptions) (interface{}, interface{}, error) {
+
+	if len(opts) == 0 {
+		opts = append(opts, TrainTestSplitOptions{})
+	}
+
+	if testSize < 0 || testSize > 1 {
+		return nil, nil, errors.New("testSize must be between 0 and 1")
+	}
+
+	if !opts[0].DontLock {
+		defer lockSDF(sdf)()
+	}
+
+	nRows := nRowsSDF(sdf)
+
+	groups, err := splitGroups(ctx, nRows, opts[0].Stratify, !opts[0].DontShuffle, opts[0].Src)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	trainRows := []int{}
+	testRows := []int{}
+
+	nTests := splitCounts(groups, int(math.Round(testSize*float64(nRows))))
+	for i, rows := range groups {
+		testRows = append(testRows, rows[:nTests[i]]...)
+		trainRows = append(trainRows, rows[nTests[i]:]...)
+	}
+
+	if opts[0].PreserveOrder {
+		sort.Ints(trainRows)
+		sort.Ints(testRows)
+	}
+
+	return dataframe.SelectRows(sdf, trainRows, dataframe.DontLock), dataframe.SelectRows(sdf, testRows, dataframe.DontLock), nil
+}
+
+// Fold contains the rows of the training set and the test (validation) set of a fold.
+type Fold struct {
+	Train []int
+	Test  []int
+}
+
+// KFoldOptions modifies the behavior of KFold.
+type KFoldOptions struct {
+
+	// Shuffle can be set to true if the rows should be shuffled before splitting.
+	// Otherwise, each test set consists of consecutive rows (unless Stratify is set).
+	Shuffle bool
+
+	// Stratify can be set to split the rows such that each fold contains approximately the same proportion
+	// of each value (class) of Stratify. It must contain a value for each row. nil is treated as a distinct class.
+	// Stratify is not locked.
+	Stratify dataframe.Series
+
+	// Src is the source of randomness. It can be set to produce reproducible splits.
+	// When nil, the global source of the math/rand package is used.
+	Src rand.Source
+
+	// DontLock can be set to true if the Series or DataFrame should not be locked.
+	DontLock bool
+}
+
+// KFold splits the rows of a Series or DataFrame into k folds. Each row is placed in the test set of exactly one fold.
+// The rows of each fold retain their original order and can be extracted using dataframe.SelectRows.
+//
+// See: https://scikit-learn.org/stable/modules/generated/sklearn.model_selection.KFold.html
+func KFold(ctx context.Context, sdf interface{}, k int, opts ...KFoldOptions) ([]Fold, error) {
+
+	if len(opts) == 0 {
+		opts = append(opts, KFoldOptions{})
+	}
+
+	if !opts[0].DontLock {
+		defer lockSDF(sdf)()
+	}
+
+	nRows := nRowsSDF(sdf)
+
+	if k < 2 || k > nRows {
+		return nil, errors.New("k must be at least 2 and not larger than the number of rows")
+	}
+
+	groups, err := splitGroups(ctx, nRows, opts[0].Stratify, opts[0].Shuffle, opts[0].Src)
+	if err != nil {
+		return nil, err
+	}
+
+	// Determine which fold each row is tested in
+	testFold := make([]int, nRows)
+
+	if opts[0].Stratify == nil {
+		// Consecutive blocks. The first nRows % k folds contain an extra row.
+		rows := groups[0]
+		start := 0
+		for f := 0; f < k; f++ {
+			size := nRows / k
+			if f < nRows%k {
+				size++
+			}
+			for _, row := range rows[start : start+size] {
+				testFold[row] = f
+			}
+			start += size
+		}
+	} else {
+		// Deal the rows of each class to the folds in turn
+		f := 0
+		for _, rows := range groups {
+			for _, row := range rows {
+				testFold[row] = f
+				f = (f + 1) % k
+			}
+		}
+	}
+
+	folds := make([]Fold, k)
+	for row := 0; row < nRows; row++ {
+		for f := range folds {
+			if testFold[row] == f {
+				folds[f].Test = append(folds[f].Test, row)
+			} else {
+				folds[f].Train = append(folds[f].Train, row)
+			}
+		}
+	}
+
+	return folds, nil
+}
+
+// splitGroups returns the rows of each class (in order of first appearance). If stratify is nil,
+// all rows belong to the same class. The rows of each class are shuffled if shuffle is set.
+func splitGroups(ctx context.Context, nRows int, stratify dataframe.Series, shuffle bool, src rand.Source) ([][]int, error) {
+
+	groups := [][]int{}
+
+	if stratify == nil {
+		rows := make([]int, nRows)
+		for i := range rows {
+			rows[i] = i
+		}
+		groups = append(groups, rows)
+	} else {
+		s := stratify
+		if s.NRows(dataframe.DontLock) != nRows {
+			return nil, errors.New("Stratify must contain a value for each row")
+		}
+
+		lookup := map[string]int{}
+		for row := 0; row < nRows; row++ {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+
+			key := "\x00" // nil
+			if s.Value(row, dataframe.DontLock) != nil {
+				key = s.ValueString(row, dataframe.DontLock)
+			}
+
+			idx, exists := lookup[key]
+			if !exists {
+				idx = len(groups)
+				lookup[key] = idx
+				groups = append(groups, []int{})
+			}
+			groups[idx] = append(groups[idx], row)
+		}
+	}
+
+	if shuffle {
+		rnd := newRand(src)
+		for _, rows := range groups {
+			rnd.Shuffle(len(rows), func(i, j int) {
+				rows[i], rows[j] = rows[j], rows[i]
+			})
+		}
+	}
+
+	return groups, nil
+}
+
+// splitCounts distributes total rows across the groups in proportion to their size. Each group receives
+// the integer part of its share. The remaining rows are given to the groups with the largest fractional parts
+// (in order of first appearance when tied).
+func splitCounts(groups [][]int, total int) []int {
+
+	var nRows int
+	for _, rows := range groups {
+		nRows += len(rows)
+	}
+
+	counts := make([]int, len(groups))
+	if nRows == 0 {
+		return counts
+	}
+
+	fracs := make([]float64, len(groups))
+	remaining := total
+	for i, rows := range groups {
+		share := float64(total) * float64(len(rows)) / float64(nRows)
+		counts[i] = int(share)
+		fracs[i] = share - float64(counts[i])
+		remaining -= counts[i]
+	}
+
+	order := make([]int, len(groups))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return fracs[order[i]] > fracs[order[j]] })
+
+	for _, i := range order {
+		if remaining <= 0 {
+			break
+		}
+		if counts[i] < len(groups[i]) {
+			counts[i]++
+			remaining--
+		}
+	}
+
+	return counts
+}
@@ -0,0 +1,136 @@
+// Copyright 2019-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package utils
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+
+	dataframe "github.com/rocketlaunchr/dataframe-go"
+)
+
+func TestTrainTestSplit(t *testing.T) {
+
+	vals := []interface{}{}
+	classes := []interface{}{}
+	for i := 0; i < 20; i++ {
+		vals = append(vals, int64(i))
+		classes = append(classes, int64(i/2)) // 10 classes of 2
+	}
+	s := dataframe.NewSeriesInt64("a", nil, vals...)
+	stratify := dataframe.NewSeriesInt64("class", nil, classes...)
+
+	tests := []struct {
+		opts  TrainTestSplitOptions
+		nTest int
+	}{
+		{TrainTestSplitOptions{Src: rand.NewSource(1)}, 4},
+		{TrainTestSplitOptions{Src: rand.NewSource(1), Stratify: stratify}, 4},
+		{TrainTestSplitOptions{DontShuffle: true}, 4},
+	}
+
+	for i, tc := range tests {
+		train, test, err := TrainTestSplit(ctx, s, 0.2, tc.opts)
+		if err != nil {
+			t.Errorf("%d: error encountered: %v", i, err)
+			continue
+		}
+
+		trainVals := int64s(train.(dataframe.Series))
+		testVals := int64s(test.(dataframe.Series))
+
+		if len(testVals) != tc.nTest || len(trainVals) != 20-tc.nTest {
+			t.Errorf("%d: wrong sizes: train: %v test: %v", i, trainVals, testVals)
+			continue
+		}
+
+		// Each row is in exactly one set
+		all := append(append([]int64{}, trainVals...), testVals...)
+		sort.Slice(all, func(i, j int) bool { return all[i] < all[j] })
+		for j, v := range all {
+			if v != int64(j) {
+				t.Errorf("%d: rows missing or duplicated: %v", i, all)
+				break
+			}
+		}
+	}
+
+	// Stratified with larger classes retains the proportion of each class
+	classes = []interface{}{}
+	for i := 0; i < 20; i++ {
+		classes = append(classes, []string{"a", "a", "a", "b"}[i%4])
+	}
+
+	_, test, err := TrainTestSplit(ctx, s, 0.5, TrainTestSplitOptions{Src: rand.NewSource(1), Stratify: dataframe.NewSeriesString("class", nil, classes...), PreserveOrder: true})
+	if err != nil {
+		t.Fatalf("error encountered: %v", err)
+	}
+
+	var nB int
+	for _, v := range int64s(test.(dataframe.Series)) {
+		if v%4 == 3 {
+			nB++
+		}
+	}
+	if n := test.(dataframe.Series).NRows(); n != 10 || (nB != 2 && nB != 3) {
+		t.Errorf("wrong stratification: %v", test)
+	}
+
+	// Reproducible
+	_, test1, _ := TrainTestSplit(ctx, s, 0.3, TrainTestSplitOptions{Src: rand.NewSource(5)})
+	_, test2, _ := TrainTestSplit(ctx, s, 0.3, TrainTestSplitOptions{Src: rand.NewSource(5)})
+	if !reflect.DeepEqual(int64s(test1.(dataframe.Series)), int64s(test2.(dataframe.Series))) {
+		t.Errorf("split not reproducible")
+	}
+}
+
+func TestKFold(t *testing.T) {
+
+	s := dataframe.NewSeriesInt64("a", nil, 0, 1, 2, 3, 4, 5, 6)
+
+	// Consecutive blocks (as in scikit-learn)
+	folds, err := KFold(ctx, s, 3)
+	if err != nil {
+		t.Fatalf("error encountered: %v", err)
+	}
+
+	expected := []Fold{
+		{Train: []int{3, 4, 5, 6}, Test: []int{0, 1, 2}},
+		{Train: []int{0, 1, 2, 5, 6}, Test: []int{3, 4}},
+		{Train: []int{0, 1, 2, 3, 4}, Test: []int{5, 6}},
+	}
+	if !reflect.DeepEqual(folds, expected) {
+		t.Errorf("wrong folds: expected: %v actual: %v", expected, folds)
+	}
+
+	// Shuffled and stratified: each row is tested exactly once
+	stratify := dataframe.NewSeriesString("class", nil, "a", "b", "a", "b", "a", "b", nil)
+
+	for _, opts := range []KFoldOptions{
+		{Shuffle: true, Src: rand.NewSource(1)},
+		{Shuffle: true, Src: rand.NewSource(1), Stratify: stratify},
+	} {
+		folds, err := KFold(ctx, s, 3, opts)
+		if err != nil {
+			t.Fatalf("error encountered: %v", err)
+		}
+
+		tested := []int{}
+		for _, f := range folds {
+			if len(f.Test)+len(f.Train) != 7 {
+				t.Errorf("wrong fold: %v", f)
+			}
+			tested = append(tested, f.Test...)
+		}
+		sort.Ints(tested)
+		if !reflect.DeepEqual(tested, []int{0, 1, 2, 3, 4, 5, 6}) {
+			t.Errorf("rows not tested exactly once: %v", tested)
+		}
+	}
+
+	if _, err := KFold(ctx, s, 8); err == nil {
+		t.Errorf("expected error when k is larger than the number of rows")
+	}
+}
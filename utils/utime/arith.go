@@ -0,0 +1,64 @@
+// Copyright 2019-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package utime
+
+import (
+	"context"
+	"time"
+
+	dataframe "github.com/rocketlaunchr/dataframe-go"
+)
+
+// Add adds period to each value of ts and returns the values as a new SeriesTime. nil values remain nil.
+//
+// See: https://godoc.org/github.com/rocketlaunchr/dataframe-go/utils/utime#TimeIntervalGenerator for setting period.
+func Add(ctx context.Context, ts *dataframe.SeriesTime, period string, opts ...dataframe.Options) (*dataframe.SeriesTime, error) {
+	return add(ctx, ts, period, false, opts...)
+}
+
+// Sub subtracts period from each value of ts and returns the values as a new SeriesTime. nil values remain nil.
+//
+// See: https://godoc.org/github.com/rocketlaunchr/dataframe-go/utils/utime#TimeIntervalGenerator for setting period.
+func Sub(ctx context.Context, ts *dataframe.SeriesTime, period string, opts ...dataframe.Options) (*dataframe.SeriesTime, error) {
+	return add(ctx, ts, period, true, opts...)
+}
+
+func add(ctx context.Context, ts *dataframe.SeriesTime, period string, reverse bool, opts ...dataframe.Options) (*dataframe.SeriesTime, error) {
+
+	d, p, err := parseTimeFreq(period)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(opts) == 0 || !opts[0].DontLock {
+		ts.Lock()
+		defer ts.Unlock()
+	}
+
+	out := dataframe.NewSeriesTime(ts.Name(dataframe.DontLock), &dataframe.SeriesInit{Capacity: len(ts.Values)})
+
+	for _, t := range ts.Values {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if t == nil {
+			out.Append(nil, dataframe.DontLock)
+			continue
+		}
+
+		var nt time.Time
+		if d != nil {
+			if reverse {
+				nt = t.Add(-*d)
+			} else {
+				nt = t.Add(*d)
+			}
+		} else {
+			nt = t.AddDate(p.addDate(reverse))
+		}
+		out.Append(nt, dataframe.DontLock)
+	}
+
+	return out, nil
+}
@@ -0,0 +1,230 @@
+// Copyright 2019-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package utime
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// Calendar is used to determine which days are holidays.
+// Holidays are skipped (in addition to weekends) by the business day frequency (B).
+type Calendar interface {
+
+	// IsHoliday returns true if the date of t (in t's time zone) is a holiday.
+	IsHoliday(t time.Time) bool
+}
+
+// CalendarFunc is an adapter to allow an ordinary function to be used as a Calendar.
+type CalendarFunc func(t time.Time) bool
+
+// IsHoliday implements the Calendar interface.
+func (f CalendarFunc) IsHoliday(t time.Time) bool {
+	return f(t)
+}
+
+type date struct {
+	year  int
+	month time.Month
+	day   int
+}
+
+type holidays map[date]struct{}
+
+func (h holidays) IsHoliday(t time.Time) bool {
+	_, exists := h[date{t.Year(), t.Month(), t.Day()}]
+	return exists
+}
+
+// NewHolidayCalendar returns a Calendar where each of dates is a holiday.
+// Only the year, month and day of each date (in its time zone) are relevant.
+//
+// Example:
+//
+//  cal := utime.NewHolidayCalendar(
+//     time.Date(2020, time.December, 25, 0, 0, 0, 0, time.UTC),
+//     time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC),
+//  )
+//
+func NewHolidayCalendar(dates ...time.Time) Calendar {
+	h := map[date]struct{}{}
+	for _, t := range dates {
+		h[date{t.Year(), t.Month(), t.Day()}] = struct{}{}
+	}
+	return holidays(h)
+}
+
+// ErrNoBusinessDay means that no business day was found within 5 years (eg. the Calendar marks every weekday as a holiday).
+var ErrNoBusinessDay = errors.New("no business day found")
+
+// maxBusinessDaySearch is the number of days that are searched for a business day before ErrNoBusinessDay is returned.
+const maxBusinessDaySearch = 5 * 366
+
+type calendarKind int
+
+const (
+	businessDay calendarKind = iota
+	monthEnd
+	quarterEnd
+	weekAnchored
+)
+
+var calendarRe = regexp.MustCompile(`^(\d*)(B|ME|QE|W-(MON|TUE|WED|THU|FRI|SAT|SUN))$`)
+
+var weekdays = map[string]time.Weekday{
+	"SUN": time.Sunday,
+	"MON": time.Monday,
+	"TUE": time.Tuesday,
+	"WED": time.Wednesday,
+	"THU": time.Thursday,
+	"FRI": time.Friday,
+	"SAT": time.Saturday,
+}
+
+// calendarFreq is a calendar-aware frequency.
+type calendarFreq struct {
+	n       int
+	kind    calendarKind
+	weekday time.Weekday // weekAnchored only
+	cal     Calendar     // businessDay only
+}
+
+// parseCalendarFreq parses timeFreq, which can be in the format: nB, nME, nQE or nW-DAY.
+// It returns false if timeFreq is not in any of those formats.
+func parseCalendarFreq(timeFreq string, cal Calendar) (*calendarFreq, bool, error) {
+
+	matches := calendarRe.FindStringSubmatch(timeFreq)
+	if len(matches) == 0 {
+		return nil, false, nil
+	}
+
+	cf := &calendarFreq{n: 1, cal: cal}
+
+	if matches[1] != "" {
+		cf.n, _ = strconv.Atoi(matches[1])
+		if cf.n == 0 {
+			return nil, true, fmt.Errorf("can't be zero: %s", timeFreq)
+		}
+	}
+
+	switch matches[2] {
+	case "B":
+		cf.kind = businessDay
+	case "ME":
+		cf.kind = monthEnd
+	case "QE":
+		cf.kind = quarterEnd
+	default:
+		cf.kind = weekAnchored
+		cf.weekday = weekdays[matches[3]]
+	}
+
+	return cf, true, nil
+}
+
+// isBusinessDay returns true if t is not on a weekend or a holiday.
+func (cf *calendarFreq) isBusinessDay(t time.Time) bool {
+	if t.Weekday() == time.Saturday || t.Weekday() == time.Sunday {
+		return false
+	}
+	return cf.cal == nil || !cf.cal.IsHoliday(t)
+}
+
+// onOffset returns true if t is on a date that is part of the frequency.
+func (cf *calendarFreq) onOffset(t time.Time) bool {
+	switch cf.kind {
+	case businessDay:
+		return cf.isBusinessDay(t)
+	case monthEnd:
+		return t.Day() == daysIn(t.Year(), t.Month())
+	case quarterEnd:
+		return t.Month()%3 == 0 && t.Day() == daysIn(t.Year(), t.Month())
+	default:
+		return t.Weekday() == cf.weekday
+	}
+}
+
+// roll returns the first date on or after t (or on or before t when reverse is true) that is part of the frequency.
+// The time of day is preserved.
+func (cf *calendarFreq) roll(t time.Time, reverse bool) (time.Time, error) {
+	for i := 0; !cf.onOffset(t); i++ {
+		if i == maxBusinessDaySearch {
+			return time.Time{}, ErrNoBusinessDay
+		}
+		t = addDays(t, dir(reverse))
+	}
+	return t, nil
+}
+
+// next returns the date that is n periods after t (or before t when reverse is true).
+// t must be part of the frequency. The time of day is preserved.
+func (cf *calendarFreq) next(t time.Time, reverse bool) (time.Time, error) {
+	switch cf.kind {
+	case businessDay:
+		for i := 0; i < cf.n; i++ {
+			t = addDays(t, dir(reverse))
+			for j := 1; !cf.isBusinessDay(t); j++ {
+				if j == maxBusinessDaySearch {
+					return time.Time{}, ErrNoBusinessDay
+				}
+				t = addDays(t, dir(reverse))
+			}
+		}
+		return t, nil
+	case monthEnd:
+		return endOfMonth(t, dir(reverse)*cf.n), nil
+	case quarterEnd:
+		return endOfMonth(t, dir(reverse)*3*cf.n), nil
+	default:
+		return addDays(t, dir(reverse)*7*cf.n), nil
+	}
+}
+
+func (cf *calendarFreq) String() string {
+	var s string
+	switch cf.kind {
+	case businessDay:
+		s = "B"
+	case monthEnd:
+		s = "ME"
+	case quarterEnd:
+		s = "QE"
+	default:
+		for k, v := range weekdays {
+			if v == cf.weekday {
+				s = "W-" + k
+			}
+		}
+	}
+
+	if cf.n == 1 {
+		return s
+	}
+	return strconv.Itoa(cf.n) + s
+}
+
+func dir(reverse bool) int {
+	if reverse {
+		return -1
+	}
+	return 1
+}
+
+// addDays adds days to t, preserving the time of day (even across daylight savings changes).
+func addDays(t time.Time, days int) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day()+days, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+}
+
+// endOfMonth returns the last day of the month that is months after t's month, preserving the time of day.
+func endOfMonth(t time.Time, months int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(months), 1, 0, 0, 0, 0, time.UTC)
+	return time.Date(first.Year(), first.Month(), daysIn(first.Year(), first.Month()), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+}
+
+// daysIn returns the number of days in a month.
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
@@ -5,7 +5,7 @@ package utime
 import (
 	"context"
 	"errors"
-	"sync"
+	"sort"
 	"time"
 
 	"github.com/icza/gox/timex"
@@ -15,12 +15,21 @@ import (
 
 var (
 	// ErrContainsNil means that the SeriesTime contains nil values.
+	//
+	// Deprecated: GuessTimeFreq now tolerates nil values.
 	ErrContainsNil = errors.New("contains nil")
 
 	// ErrNoPattern means that no pattern was detected.
 	ErrNoPattern = errors.New("no pattern detected")
 )
 
+// minGuessCandidates is the minimum number of candidate timeFreqs (excluding Hint) that GuessTimeFreqs evaluates.
+// The candidates are the most frequently observed intervals.
+const minGuessCandidates = 10
+
+// maxGuessBusinessDays is the largest business day frequency that is attempted.
+const maxGuessBusinessDays = 260
+
 // GuessTimeFreqOptions configures how GuessTimeFreq behaves.
 type GuessTimeFreqOptions struct {
 
@@ -30,10 +39,35 @@ type GuessTimeFreqOptions struct {
 	// R is used to limit the range of the Series.
 	R *dataframe.Range
 
+	// Calendar sets the holidays that are skipped by the business day frequency (B).
+	Calendar Calendar
+
 	// DontLock can be set to true if the Series should not be locked.
 	DontLock bool
 }
 
+// TimeFreqGuess is a candidate timeFreq returned by GuessTimeFreqs.
+type TimeFreqGuess struct {
+
+	// TimeFreq is compatible with TimeIntervalGenerator.
+	TimeFreq string
+
+	// Reverse indicates whether the sequence is backwards.
+	Reverse bool
+
+	// Confidence is the fraction (from 0 to 1) of intervals between consecutive non-nil values
+	// that are exactly one TimeFreq per row. Intervals that span missing periods don't match.
+	Confidence float64
+
+	// Gaps are the ranges of rows that contain nil values or span missing periods.
+	// Each range begins and ends at a value that lies on TimeFreq (unless it extends to the
+	// start or end of the Series). It can be used as the R option of forecast.Interpolate.
+	Gaps []dataframe.Range
+
+	// Outliers are the ranges of rows containing values that don't lie on TimeFreq.
+	Outliers []dataframe.Range
+}
+
 // GuessTimeFreq will attempt to guess the time interval in a SeriesTime.
 // It will return a string that is compatible with TimeIntervalGenerator.
 // It will also return a bool indicating whether the sequence is backwards.
@@ -41,9 +75,45 @@ type GuessTimeFreqOptions struct {
 // Due to daylight savings and varying days per month, it is not always possible to
 // determine a pattern with 100% confidence.
 //
-// NOTE: As currently implemented, nil values are not tolerated.
+// nil values and missing periods are skipped, but every non-nil value must lie on the most likely timeFreq.
+// See GuessTimeFreqs for obtaining multiple candidates along with the gaps and outliers.
 func GuessTimeFreq(ctx context.Context, ts *dataframe.SeriesTime, opts ...GuessTimeFreqOptions) (string, bool, error) {
 
+	guesses, err := GuessTimeFreqs(ctx, ts, 1, opts...)
+	if err != nil {
+		return "", false, err
+	}
+
+	if len(guesses[0].Outliers) != 0 {
+		return "", guesses[0].Reverse, ErrNoPattern
+	}
+	return guesses[0].TimeFreq, guesses[0].Reverse, nil
+}
+
+// GuessTimeFreqs will attempt to guess the time interval in a SeriesTime.
+// It returns up to n candidates, ordered by decreasing confidence (and then by fewest outliers).
+//
+// Candidates are derived from the intervals between consecutive non-nil values. nil values and missing
+// periods are skipped. The direction of the sequence is determined by the first and last non-nil values.
+// Business day (nB), quarter-end (nQE) and month-end (nME) frequencies are also attempted.
+// Weekly-anchored frequencies (eg. W-FRI) are only attempted if set as Hint.
+//
+// Example:
+//
+//  guesses, _ := utime.GuessTimeFreqs(ctx, ts, 3)
+//  best := guesses[0]
+//
+//  // Fill nil values
+//  for _, r := range best.Gaps {
+//     forecast.Interpolate(ctx, fs, forecast.InterpolateOptions{R: &r, InPlace: true})
+//  }
+//
+func GuessTimeFreqs(ctx context.Context, ts *dataframe.SeriesTime, n int, opts ...GuessTimeFreqOptions) ([]TimeFreqGuess, error) {
+
+	if n <= 0 {
+		panic("n must be greater than 0")
+	}
+
 	if len(opts) == 0 {
 		opts = append(opts, GuessTimeFreqOptions{R: &dataframe.Range{}})
 	} else if opts[0].R == nil {
@@ -55,54 +125,54 @@ func GuessTimeFreq(ctx context.Context, ts *dataframe.SeriesTime, opts ...GuessT
 		defer ts.Unlock()
 	}
 
-	// Check for nil, even if R is set to a sub-range
-	if ts.ContainsNil(dataframe.DontLock) {
-		return "", false, ErrContainsNil
+	start, end, err := opts[0].R.Limits(len(ts.Values))
+	if err != nil {
+		return nil, err
 	}
 
-	nRows := ts.NRows(dataframe.DontLock)
-
-	start, end, err := opts[0].R.Limits(nRows)
-	if err != nil {
-		return "", false, err
+	// Find rows with non-nil values
+	rows := []int{}
+	for row := start; row <= end; row++ {
+		if ts.Values[row] != nil {
+			rows = append(rows, row)
+		}
 	}
 
-	if (end - start) < 1 {
-		return "", false, ErrNoPattern
+	if len(rows) < 2 {
+		return nil, ErrNoPattern
 	}
 
 	// Determine if reverse
-	reverse := false
+	first := *ts.Values[rows[0]]
+	last := *ts.Values[rows[len(rows)-1]]
 
-	val1 := *ts.Values[start]
-	val2 := *ts.Values[start+1]
-
-	if val1.Equal(val2) {
-		return "", false, ErrNoPattern
-	} else if val1.After(val2) {
-		reverse = true
+	if first.Equal(last) {
+		return nil, ErrNoPattern
 	}
+	reverse := first.After(last)
 
-	// Check if Hint is correct
-	if opts[0].Hint != "" {
-		err := ValidateSeriesTime(ctx, ts, opts[0].Hint, ValidateSeriesTimeOptions{DontLock: true})
-		if err == nil {
-			return opts[0].Hint, reverse, nil
+	// Find all possible timeFreq
+	candidates := []string{}
+	counts := map[string]int{}
+
+	addCandidate := func(timeFreq string) {
+		if timeFreq == opts[0].Hint {
+			return
+		}
+		if _, exists := counts[timeFreq]; !exists {
+			candidates = append(candidates, timeFreq)
 		}
+		counts[timeFreq]++
 	}
 
-	var wg sync.WaitGroup
-
-	var (
-		// Could be an error or string
-		ret1 interface{}
-		ret2 interface{}
-	)
+	for i := 1; i < len(rows); i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 
-	// Use time.Duration
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
+		val1 := *ts.Values[rows[i-1]]
+		val2 := *ts.Values[rows[i]]
+		nPeriods := rows[i] - rows[i-1]
 
 		var d time.Duration
 		if reverse {
@@ -111,119 +181,226 @@ func GuessTimeFreq(ctx context.Context, ts *dataframe.SeriesTime, opts ...GuessT
 			d = val2.Sub(val1)
 		}
 
-		timeFreq := d.String()
+		if d <= 0 {
+			continue
+		}
+
+		if nPeriods == 1 {
+			for _, timeFreq := range calendarCandidates(val1, val2, reverse, opts[0].Calendar) {
+				addCandidate(timeFreq)
+			}
+
+			years, months, days, hours, mins, secs := timex.Diff(val1, val2)
+			if hours == 0 && mins == 0 && secs == 0 { // Compatible with TimeIntervalGenerator
+				if p := (parsed{years, months, 0, days}); !p.isZero() {
+					addCandidate(p.String())
+				}
+			}
+		}
+
+		if d%time.Duration(nPeriods) == 0 {
+			addCandidate((d / time.Duration(nPeriods)).String())
+		}
+	}
+
+	// Evaluate the most frequently observed candidates
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return counts[candidates[i]] > counts[candidates[j]]
+	})
+
+	limit := minGuessCandidates
+	if n > limit {
+		limit = n
+	}
+	if len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+
+	if opts[0].Hint != "" {
+		candidates = append([]string{opts[0].Hint}, candidates...)
+	}
 
-		err := ValidateSeriesTime(ctx, ts, timeFreq, ValidateSeriesTimeOptions{DontLock: true})
+	guesses := []TimeFreqGuess{}
+	for _, timeFreq := range candidates {
+		guess, err := evaluateTimeFreq(ctx, ts, start, end, rows, timeFreq, reverse, opts[0].Calendar)
 		if err != nil {
-			ret1 = err
-		} else {
-			ret1 = timeFreq
+			if err == context.Canceled || err == context.DeadlineExceeded || err == ErrNoBusinessDay {
+				return nil, err
+			}
+			continue // Invalid Hint
 		}
-	}()
+		guesses = append(guesses, guess)
+	}
 
-	if !((reverse && val1.Sub(val2) < 23*time.Hour) || (val2.Sub(val1) < 23*time.Hour)) {
-		// Use github.com/icza/gox/timex
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
+	if len(guesses) == 0 {
+		return nil, ErrNoPattern
+	}
 
-			// Find all possible timeFreq
-			candidates := map[parsed]struct{}{}
-			for i := start; i < end; i++ {
+	sort.SliceStable(guesses, func(i, j int) bool {
+		if guesses[i].Confidence == guesses[j].Confidence {
+			return len(guesses[i].Outliers) < len(guesses[j].Outliers)
+		}
+		return guesses[i].Confidence > guesses[j].Confidence
+	})
 
-				if err := ctx.Err(); err != nil {
-					ret2 = err
-					return
-				}
+	if len(guesses) > n {
+		guesses = guesses[:n]
+	}
+	return guesses, nil
+}
 
-				val1 := *ts.Values[i]
-				val2 := *ts.Values[i+1]
+// evaluateTimeFreq determines how well the non-nil values (found in rows) match timeFreq.
+func evaluateTimeFreq(ctx context.Context, ts *dataframe.SeriesTime, start, end int, rows []int, timeFreq string, reverse bool, cal Calendar) (TimeFreqGuess, error) {
 
-				var years, months, days, hours, mins, secs int
+	gen, err := timeIntervalGenerator(timeFreq, cal)
+	if err != nil {
+		return TimeFreqGuess{}, err
+	}
 
-				if reverse {
-					years, months, days, hours, mins, secs = timex.Diff(val1, val2)
-				} else {
-					years, months, days, hours, mins, secs = timex.Diff(val2, val1)
-				}
+	guess := TimeFreqGuess{TimeFreq: timeFreq, Reverse: reverse, Gaps: []dataframe.Range{}}
 
-				if hours != 0 || mins != 0 || secs != 0 { // Not compatible with TimeIntervalGenerator
-					ret2 = ErrNoPattern
-					return
-				}
+	if rows[0] > start {
+		guess.Gaps = append(guess.Gaps, dataframe.RangeFinite(start, rows[0]))
+	}
 
-				candidates[parsed{years, months, 0, days}] = struct{}{}
-			}
+	var (
+		outliers []int
+		matches  int
+		anchor   = -1 // last row that lies on timeFreq
+		nPeriods int  // number of periods between anchor and expected
+	)
+
+	// For a fixed duration, the expected value is located directly (rather than stepping)
+	// so that small durations remain fast.
+	var step time.Duration
+	if _, ok, _ := parseCalendarFreq(timeFreq, cal); !ok {
+		if d, _, _ := parseTimeFreq(timeFreq); d != nil {
+			step = *d
+		}
+	}
+
+	base := *ts.Values[rows[0]]
+	ntg := gen(base, reverse)
+	expected, err := ntg()
+	if err != nil {
+		return TimeFreqGuess{}, err
+	}
+	expectedIdx := 0 // number of steps from base to expected (only used for a fixed duration)
+
+	for _, row := range rows {
+		if err := ctx.Err(); err != nil {
+			return TimeFreqGuess{}, err
+		}
 
-			// Evaluate each candidate timeFreq
-			newCtx, cancel := context.WithCancel(ctx)
-			defer cancel()
-
-			var wg sync.WaitGroup
-			var lock sync.Mutex
-			var foundTimeFreq string
-
-			for k := range candidates {
-				timeFreq := k.String()
-
-				wg.Add(1)
-				go func() {
-					defer wg.Done()
-
-					err := ValidateSeriesTime(newCtx, ts, timeFreq, ValidateSeriesTimeOptions{DontLock: true})
-					if err == nil {
-						// We found a timeFreq
-						cancel()
-						lock.Lock()
-						foundTimeFreq = timeFreq
-						lock.Unlock()
-					}
-				}()
+		val := *ts.Values[row]
+
+		if step > 0 {
+			elapsed := val.Sub(base)
+			if reverse {
+				elapsed = -elapsed
 			}
 
-			wg.Wait()
+			// Advance expected to the first value on or after val
+			if idx := elapsed / step; elapsed > time.Duration(expectedIdx)*step {
+				if elapsed%step != 0 {
+					idx++
+				}
+				nPeriods += int(idx) - expectedIdx
+				expectedIdx = int(idx)
+			}
 
-			if foundTimeFreq != "" {
-				ret2 = foundTimeFreq
-			} else {
+			if elapsed != time.Duration(expectedIdx)*step {
+				outliers = append(outliers, row)
+				continue
+			}
+		} else {
+			for (!reverse && expected.Before(val)) || (reverse && expected.After(val)) {
 				if err := ctx.Err(); err != nil {
-					ret2 = err
-				} else {
-					ret2 = ErrNoPattern
+					return TimeFreqGuess{}, err
 				}
+				expected, err = ntg()
+				if err != nil {
+					return TimeFreqGuess{}, err
+				}
+				nPeriods++
 			}
-		}()
-	} else {
-		// DST has the potential to make a day 23 hours.
-		// This scenario is less than 23 hours.
-		ret2 = ErrNoPattern
-	}
 
-	wg.Wait()
+			if !expected.Equal(val) {
+				outliers = append(outliers, row)
+				continue
+			}
+		}
 
-	err1, ok1 := ret1.(error)
-	err2, ok2 := ret2.(error)
+		if anchor != -1 {
+			if nPeriods == row-anchor {
+				matches++
+			}
+			if nPeriods > 1 || row-anchor > 1 {
+				guess.Gaps = append(guess.Gaps, dataframe.RangeFinite(anchor, row))
+			}
+		}
 
-	// If either was an error, then check if it was due to context cancelation.
-	if ok1 && (err1 == context.Canceled || err1 == context.DeadlineExceeded) {
-		return "", reverse, err1
+		anchor = row
+		nPeriods = 1
+		if step > 0 {
+			expectedIdx++
+		} else {
+			expected, err = ntg()
+			if err != nil {
+				return TimeFreqGuess{}, err
+			}
+		}
 	}
-	if ok2 && (err2 == context.Canceled || err2 == context.DeadlineExceeded) {
-		return "", reverse, err2
+
+	if last := rows[len(rows)-1]; last < end {
+		guess.Gaps = append(guess.Gaps, dataframe.RangeFinite(last, end))
 	}
 
-	if ok1 {
-		if ok2 {
-			// Both are errors
-			return "", reverse, ErrNoPattern
+	guess.Confidence = float64(matches) / float64(len(rows)-1)
+	guess.Outliers = dataframe.IntsToRanges(outliers)
+
+	return guess, nil
+}
+
+// calendarCandidates returns the calendar-aware frequencies that val1 and val2 are consecutive values of.
+func calendarCandidates(val1, val2 time.Time, reverse bool, cal Calendar) []string {
+
+	candidates := []string{}
+
+	// Business days
+	bd := &calendarFreq{n: 1, kind: businessDay, cal: cal}
+	if bd.isBusinessDay(val1) && bd.isBusinessDay(val2) {
+		t := val1
+		for n := 1; n <= maxGuessBusinessDays; n++ {
+			var err error
+			t, err = bd.next(t, reverse)
+			if err != nil {
+				break
+			}
+			if t.Equal(val2) {
+				candidates = append(candidates, (&calendarFreq{n: n, kind: businessDay}).String())
+				break
+			}
+			if (!reverse && t.After(val2)) || (reverse && t.Before(val2)) {
+				break
+			}
 		}
-		// ret1 is error. ret2 is string
-		return ret2.(string), reverse, nil
 	}
-	if ok2 {
-		// ret2 is error. ret1 is string
-		return ret1.(string), reverse, nil
+
+	// Quarter ends and month ends
+	me := &calendarFreq{kind: monthEnd}
+	if me.onOffset(val1) && me.onOffset(val2) {
+		months := (val2.Year()-val1.Year())*12 + int(val2.Month()-val1.Month())
+		if reverse {
+			months = -months
+		}
+		if months > 0 {
+			if months%3 == 0 && (&calendarFreq{kind: quarterEnd}).onOffset(val1) {
+				candidates = append(candidates, (&calendarFreq{n: months / 3, kind: quarterEnd}).String())
+			}
+			candidates = append(candidates, (&calendarFreq{n: months, kind: monthEnd}).String())
+		}
 	}
-	// Both are strings
-	return ret2.(string), reverse, nil
+
+	return candidates
 }
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"regexp"
 	"strconv"
+	"time"
 )
 
 var re = regexp.MustCompile(`^(\d+Y)?(\d+M)?(\d+W)?(\d+D)?$`)
@@ -85,3 +86,30 @@ func parseComponent(s string) int {
 	n, _ := strconv.Atoi(s)
 	return n
 }
+
+// parseTimeFreq parses timeFreq, which can be in the format: nYnMnWnD or a valid positive input
+// to time.ParseDuration. Exactly one of the returned values is not nil.
+func parseTimeFreq(timeFreq string) (*time.Duration, *parsed, error) {
+
+	// Prevent negative sign
+	if len(timeFreq) > 0 && timeFreq[0:1] == "-" {
+		return nil, nil, fmt.Errorf("negative sign disallowed: %s", timeFreq)
+	}
+
+	d, err := time.ParseDuration(timeFreq)
+	if err != nil {
+		p, err := parse(timeFreq)
+		if err != nil {
+			return nil, nil, fmt.Errorf("could not parse: %s", timeFreq)
+		}
+		if p.isZero() {
+			return nil, nil, fmt.Errorf("can't be zero: %s", timeFreq)
+		}
+		return nil, &p, nil
+	}
+
+	if d == 0 {
+		return nil, nil, fmt.Errorf("can't be zero: %s", timeFreq)
+	}
+	return &d, nil, nil
+}
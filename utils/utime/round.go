@@ -0,0 +1,144 @@
+// Copyright 2019-20 PJ Engineering and Business Solutions Pty. Ltd. All rights reserved.
+
+package utime
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	dataframe "github.com/rocketlaunchr/dataframe-go"
+)
+
+// Floor rounds each value of ts down to a multiple of timeFreq and returns the values as a new SeriesTime.
+// nil values remain nil.
+//
+// timeFreq can be in the format: nY, nM, nW or nD, where n is a positive integer (only one component is permitted).
+// The values are rounded down to the start of a year, month, week (Monday) or day in their time zone.
+// For n > 1, the multiples are counted from the year 0, month 0 (January of year 0), the week of 1970-01-05 and
+// 1970-01-01 respectively.
+// Alternatively, timeFreq can be a valid positive input to time.ParseDuration, in which case the multiples are
+// counted from the zero time.
+//
+// See: https://golang.org/pkg/time/#Time.Truncate
+func Floor(ctx context.Context, ts *dataframe.SeriesTime, timeFreq string, opts ...dataframe.Options) (*dataframe.SeriesTime, error) {
+	return round(ctx, ts, timeFreq, func(t, floor, ceil time.Time) time.Time {
+		return floor
+	}, opts...)
+}
+
+// Ceil rounds each value of ts up to a multiple of timeFreq and returns the values as a new SeriesTime.
+// nil values remain nil.
+//
+// See: https://godoc.org/github.com/rocketlaunchr/dataframe-go/utils/utime#Floor for setting timeFreq.
+func Ceil(ctx context.Context, ts *dataframe.SeriesTime, timeFreq string, opts ...dataframe.Options) (*dataframe.SeriesTime, error) {
+	return round(ctx, ts, timeFreq, func(t, floor, ceil time.Time) time.Time {
+		return ceil
+	}, opts...)
+}
+
+// Round rounds each value of ts to the nearest multiple of timeFreq and returns the values as a new SeriesTime.
+// Halfway values are rounded up. nil values remain nil.
+//
+// See: https://godoc.org/github.com/rocketlaunchr/dataframe-go/utils/utime#Floor for setting timeFreq.
+func Round(ctx context.Context, ts *dataframe.SeriesTime, timeFreq string, opts ...dataframe.Options) (*dataframe.SeriesTime, error) {
+	return round(ctx, ts, timeFreq, func(t, floor, ceil time.Time) time.Time {
+		if t.Sub(floor) < ceil.Sub(t) {
+			return floor
+		}
+		return ceil
+	}, opts...)
+}
+
+func round(ctx context.Context, ts *dataframe.SeriesTime, timeFreq string, choose func(t, floor, ceil time.Time) time.Time, opts ...dataframe.Options) (*dataframe.SeriesTime, error) {
+
+	d, p, err := parseTimeFreq(timeFreq)
+	if err != nil {
+		return nil, err
+	}
+
+	if p != nil {
+		var n int
+		for _, c := range []int{p.years, p.months, p.weeks, p.days} {
+			if c != 0 {
+				n++
+			}
+		}
+		if n > 1 {
+			return nil, fmt.Errorf("only one component permitted: %s", timeFreq)
+		}
+	}
+
+	if len(opts) == 0 || !opts[0].DontLock {
+		ts.Lock()
+		defer ts.Unlock()
+	}
+
+	out := dataframe.NewSeriesTime(ts.Name(dataframe.DontLock), &dataframe.SeriesInit{Capacity: len(ts.Values)})
+
+	for _, t := range ts.Values {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if t == nil {
+			out.Append(nil, dataframe.DontLock)
+			continue
+		}
+
+		var floor, ceil time.Time
+		if d != nil {
+			floor = t.Truncate(*d)
+			ceil = floor
+			if !floor.Equal(*t) {
+				ceil = floor.Add(*d)
+			}
+		} else {
+			floor = floorPeriod(*t, *p)
+			ceil = floor
+			if !floor.Equal(*t) {
+				ceil = floor.AddDate(p.addDate(false))
+			}
+		}
+
+		out.Append(choose(*t, floor, ceil), dataframe.DontLock)
+	}
+
+	return out, nil
+}
+
+// floorPeriod rounds t down to a multiple of p, which must contain exactly one component.
+func floorPeriod(t time.Time, p parsed) time.Time {
+
+	loc := t.Location()
+
+	// days is the number of days since 1970-01-01 (based on the wall clock)
+	days := int(time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC).Unix() / 86400)
+
+	switch {
+	case p.years != 0:
+		y := t.Year() - floorMod(t.Year(), p.years)
+		return time.Date(y, time.January, 1, 0, 0, 0, 0, loc)
+	case p.months != 0:
+		m := t.Year()*12 + int(t.Month()) - 1
+		m -= floorMod(m, p.months)
+		return time.Date(m/12, time.Month(m%12+1), 1, 0, 0, 0, 0, loc)
+	case p.weeks != 0:
+		// 1970-01-05 was a Monday
+		days -= 4
+		days -= floorMod(days, 7*p.weeks)
+		return time.Date(1970, time.January, 5+days, 0, 0, 0, 0, loc)
+	default:
+		days -= floorMod(days, p.days)
+		return time.Date(1970, time.January, 1+days, 0, 0, 0, 0, loc)
+	}
+}
+
+// floorMod returns a modulo n, which is always non-negative.
+func floorMod(a, n int) int {
+	m := a % n
+	if m < 0 {
+		m += n
+	}
+	return m
+}
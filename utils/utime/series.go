@@ -19,6 +19,9 @@ type NewSeriesTimeOptions struct {
 	// Until is the maximum time in the generated Series.
 	// This option can't be used with Size option.
 	Until *time.Time
+
+	// Calendar sets the holidays that are skipped by the business day frequency (B).
+	Calendar Calendar
 }
 
 // NewSeriesTime will create a new SeriesTime with timeFreq prescribing the intervals between each row. Setting reverse will make the time series decrement per row.
@@ -54,7 +57,7 @@ func NewSeriesTime(ctx context.Context, name string, timeFreq string, startTime
 		times = []*time.Time{}
 	}
 
-	gen, err := TimeIntervalGenerator(timeFreq)
+	gen, err := timeIntervalGenerator(timeFreq, opts.Calendar)
 	if err != nil {
 		return nil, err
 	}
@@ -69,7 +72,10 @@ func NewSeriesTime(ctx context.Context, name string, timeFreq string, startTime
 			break
 		}
 
-		nt := ntg()
+		nt, err := ntg()
+		if err != nil {
+			return nil, err
+		}
 
 		if opts.Until != nil {
 			if reverse {
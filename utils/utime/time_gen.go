@@ -3,7 +3,6 @@
 package utime
 
 import (
-	"fmt"
 	"time"
 )
 
@@ -21,6 +20,17 @@ type TimeGenerator func(startTime time.Time, reverse bool) NextTime
 // Y, M, W and D represent years, months, weeks and days respectively. Alternatively, timeFreq
 // can be a valid positive input to time.ParseDuration.
 //
+// timeFreq can also be a calendar-aware frequency, where n is an optional positive integer:
+//
+//  nB: business days (Monday to Friday, excluding the holidays of cal)
+//  nME: month ends
+//  nQE: quarter ends (March, June, September and December)
+//  nW-DAY: weeks anchored on DAY (MON, TUE, WED, THU, FRI, SAT or SUN). eg. W-FRI
+//
+// For calendar-aware frequencies, the sequence begins at the first date on or after startTime
+// (or on or before startTime when reverse is true) that is part of the frequency. The time of day is preserved.
+// NextTime panics with ErrNoBusinessDay if no business day is found within 5 years.
+//
 // Example:
 //
 //  gen, _ := utime.TimeIntervalGenerator("1W1D")
@@ -31,39 +41,72 @@ type TimeGenerator func(startTime time.Time, reverse bool) NextTime
 //  }
 //
 // See: https://golang.org/pkg/time/#ParseDuration
-func TimeIntervalGenerator(timeFreq string) (TimeGenerator, error) {
+func TimeIntervalGenerator(timeFreq string, cal ...Calendar) (TimeGenerator, error) {
 
-	// Prevent negative sign
-	if len(timeFreq) > 0 && timeFreq[0:1] == "-" {
-		return nil, fmt.Errorf("negative sign disallowed: %s", timeFreq)
+	var c Calendar
+	if len(cal) > 0 {
+		c = cal[0]
 	}
 
-	var (
-		d *time.Duration
-		p *parsed
-	)
-
-	_d, err := time.ParseDuration(timeFreq)
+	gen, err := timeIntervalGenerator(timeFreq, c)
 	if err != nil {
-		_p, err := parse(timeFreq)
-		if err != nil {
-			return nil, fmt.Errorf("could not parse: %s", timeFreq)
-		}
-		if _p.isZero() {
-			return nil, fmt.Errorf("can't be zero: %s", timeFreq)
-		}
-		p = &_p
-	} else {
-		if _d == 0 {
-			return nil, fmt.Errorf("can't be zero: %s", timeFreq)
-		}
-		d = &_d
+		return nil, err
 	}
 
 	return func(startTime time.Time, reverse bool) NextTime {
-		var prevTime *time.Time
+		ntg := gen(startTime, reverse)
 
 		return func() time.Time {
+			nt, err := ntg()
+			if err != nil {
+				panic(err)
+			}
+			return nt
+		}
+	}, nil
+}
+
+// timeIntervalGenerator is the same as TimeIntervalGenerator, except that the generated
+// sequence returns an error instead of panicking.
+func timeIntervalGenerator(timeFreq string, cal Calendar) (func(startTime time.Time, reverse bool) func() (time.Time, error), error) {
+
+	cf, ok, err := parseCalendarFreq(timeFreq, cal)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		return func(startTime time.Time, reverse bool) func() (time.Time, error) {
+			var prevTime *time.Time
+
+			return func() (time.Time, error) {
+				var (
+					nt  time.Time
+					err error
+				)
+
+				if prevTime == nil {
+					nt, err = cf.roll(startTime, reverse)
+				} else {
+					nt, err = cf.next(*prevTime, reverse)
+				}
+				if err != nil {
+					return time.Time{}, err
+				}
+				prevTime = &nt
+				return nt, nil
+			}
+		}, nil
+	}
+
+	d, p, err := parseTimeFreq(timeFreq)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(startTime time.Time, reverse bool) func() (time.Time, error) {
+		var prevTime *time.Time
+
+		return func() (time.Time, error) {
 			var nt time.Time
 
 			if prevTime == nil {
@@ -80,7 +123,7 @@ func TimeIntervalGenerator(timeFreq string) (TimeGenerator, error) {
 				}
 			}
 			prevTime = &nt
-			return nt
+			return nt, nil
 		}
 	}, nil
 }
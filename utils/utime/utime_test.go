@@ -2,8 +2,11 @@ package utime
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
+
+	dataframe "github.com/rocketlaunchr/dataframe-go"
 )
 
 func TestUtime(t *testing.T) {
@@ -60,3 +63,289 @@ func TestGuessTimeFreq(t *testing.T) {
 		}
 	}
 }
+
+func TestRound(t *testing.T) {
+	ctx := context.Background()
+
+	t1 := time.Date(2020, time.May, 14, 13, 40, 0, 0, time.UTC) // Thursday
+	ts := dataframe.NewSeriesTime("time", nil, t1, nil)
+
+	tests := []struct {
+		fn       func(context.Context, *dataframe.SeriesTime, string, ...dataframe.Options) (*dataframe.SeriesTime, error)
+		timeFreq string
+		expected time.Time
+	}{
+		{Floor, "1h", time.Date(2020, time.May, 14, 13, 0, 0, 0, time.UTC)},
+		{Ceil, "1h", time.Date(2020, time.May, 14, 14, 0, 0, 0, time.UTC)},
+		{Round, "1h", time.Date(2020, time.May, 14, 14, 0, 0, 0, time.UTC)},
+		{Round, "1D", time.Date(2020, time.May, 15, 0, 0, 0, 0, time.UTC)},
+		{Floor, "1W", time.Date(2020, time.May, 11, 0, 0, 0, 0, time.UTC)},
+		{Ceil, "1W", time.Date(2020, time.May, 18, 0, 0, 0, 0, time.UTC)},
+		{Floor, "3M", time.Date(2020, time.April, 1, 0, 0, 0, 0, time.UTC)},
+		{Round, "1M", time.Date(2020, time.May, 1, 0, 0, 0, 0, time.UTC)},
+		{Ceil, "10Y", time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for i, tc := range tests {
+		out, err := tc.fn(ctx, ts, tc.timeFreq)
+		if err != nil {
+			t.Errorf("%d: error encountered: %v", i, err)
+			continue
+		}
+
+		if !out.Values[0].Equal(tc.expected) || out.Values[1] != nil {
+			t.Errorf("%d: wrong val: expected: %v actual: %v", i, tc.expected, out.Values[0])
+		}
+	}
+
+	if _, err := Floor(ctx, ts, "1M1D"); err == nil {
+		t.Errorf("expected error for multiple components")
+	}
+}
+
+func TestAdd(t *testing.T) {
+	ctx := context.Background()
+
+	t1 := time.Date(2020, time.January, 31, 12, 0, 0, 0, time.UTC)
+	ts := dataframe.NewSeriesTime("time", nil, t1, nil)
+
+	out, err := Add(ctx, ts, "1M1D")
+	if err != nil {
+		t.Fatalf("error encountered: %v", err)
+	}
+
+	expected := time.Date(2020, time.March, 3, 12, 0, 0, 0, time.UTC)
+	if !out.Values[0].Equal(expected) || out.Values[1] != nil {
+		t.Errorf("wrong val: expected: %v actual: %v", expected, out.Values[0])
+	}
+
+	out, err = Sub(ctx, ts, "36h")
+	if err != nil {
+		t.Fatalf("error encountered: %v", err)
+	}
+
+	expected = time.Date(2020, time.January, 30, 0, 0, 0, 0, time.UTC)
+	if !out.Values[0].Equal(expected) {
+		t.Errorf("wrong val: expected: %v actual: %v", expected, out.Values[0])
+	}
+}
+
+func TestCalendarFreq(t *testing.T) {
+	ctx := context.Background()
+
+	date := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 9, 0, 0, 0, time.UTC)
+	}
+
+	cal := NewHolidayCalendar(date(2020, time.December, 25), date(2021, time.January, 1))
+
+	tests := []struct {
+		timeFreq string
+		start    time.Time
+		reverse  bool
+		cal      Calendar
+		expected []time.Time
+	}{
+		{"B", date(2020, time.December, 24), false, cal, []time.Time{date(2020, time.December, 24), date(2020, time.December, 28), date(2020, time.December, 29), date(2020, time.December, 30), date(2020, time.December, 31), date(2021, time.January, 4)}},
+		{"B", date(2020, time.December, 26), true, cal, []time.Time{date(2020, time.December, 24), date(2020, time.December, 23)}},
+		{"2B", date(2020, time.December, 24), false, nil, []time.Time{date(2020, time.December, 24), date(2020, time.December, 28), date(2020, time.December, 30)}},
+		{"ME", date(2020, time.January, 15), false, nil, []time.Time{date(2020, time.January, 31), date(2020, time.February, 29), date(2020, time.March, 31)}},
+		{"QE", date(2020, time.January, 15), false, nil, []time.Time{date(2020, time.March, 31), date(2020, time.June, 30), date(2020, time.September, 30)}},
+		{"W-FRI", date(2020, time.January, 1), false, nil, []time.Time{date(2020, time.January, 3), date(2020, time.January, 10), date(2020, time.January, 17)}},
+	}
+
+	for i, tc := range tests {
+		opts := NewSeriesTimeOptions{Size: &[]int{len(tc.expected)}[0], Calendar: tc.cal}
+
+		ts, err := NewSeriesTime(ctx, "time", tc.timeFreq, tc.start, tc.reverse, opts)
+		if err != nil {
+			t.Fatalf("%d: error encountered: %v", i, err)
+		}
+
+		for j, expected := range tc.expected {
+			if !ts.Values[j].Equal(expected) {
+				t.Errorf("%d: wrong val: expected: %v actual: %v", i, expected, ts.Values[j])
+			}
+		}
+
+		err = ValidateSeriesTime(ctx, ts, tc.timeFreq, ValidateSeriesTimeOptions{Calendar: tc.cal})
+		if err != nil {
+			t.Errorf("%d: validation failed: %v", i, err)
+		}
+
+		guess, gReverse, err := GuessTimeFreq(ctx, ts, GuessTimeFreqOptions{Hint: tc.timeFreq, Calendar: tc.cal})
+		if err != nil || guess != tc.timeFreq || gReverse != tc.reverse {
+			t.Errorf("%d: wrong guess: expected: %v actual: %v (%v)", i, tc.timeFreq, guess, err)
+		}
+	}
+
+	if _, err := TimeIntervalGenerator("0B"); err == nil {
+		t.Errorf("expected error for zero frequency")
+	}
+
+	// Every weekday is a holiday
+	noBusinessDays := CalendarFunc(func(t time.Time) bool { return true })
+	weekend := dataframe.NewSeriesTime("time", nil, date(2020, time.January, 4), date(2020, time.January, 5))
+
+	if _, err := NewSeriesTime(ctx, "time", "B", date(2020, time.January, 4), false, NewSeriesTimeOptions{Size: &[]int{2}[0], Calendar: noBusinessDays}); err != ErrNoBusinessDay {
+		t.Errorf("wrong error: expected: %v actual: %v", ErrNoBusinessDay, err)
+	}
+
+	if err := ValidateSeriesTime(ctx, weekend, "B", ValidateSeriesTimeOptions{Calendar: noBusinessDays}); err != ErrNoBusinessDay {
+		t.Errorf("wrong error: expected: %v actual: %v", ErrNoBusinessDay, err)
+	}
+
+	if _, err := GuessTimeFreqs(ctx, weekend, 1, GuessTimeFreqOptions{Hint: "B", Calendar: noBusinessDays}); err != ErrNoBusinessDay {
+		t.Errorf("wrong error: expected: %v actual: %v", ErrNoBusinessDay, err)
+	}
+
+	gen, _ := TimeIntervalGenerator("B", noBusinessDays)
+	func() {
+		defer func() {
+			if r := recover(); r != ErrNoBusinessDay {
+				t.Errorf("wrong panic: expected: %v actual: %v", ErrNoBusinessDay, r)
+			}
+		}()
+		gen(date(2020, time.January, 4), false)()
+	}()
+}
+
+func TestGuessCalendarFreq(t *testing.T) {
+	ctx := context.Background()
+
+	start := time.Date(2020, time.December, 24, 0, 0, 0, 0, time.UTC)
+	cal := NewHolidayCalendar(time.Date(2020, time.December, 25, 0, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		timeFreq string
+		cal      Calendar
+	}{
+		{"B", cal},
+		{"3B", nil},
+		{"ME", nil},
+		{"2ME", nil},
+		{"QE", nil},
+	}
+
+	for i, tc := range tests {
+		opts := NewSeriesTimeOptions{Size: &[]int{12}[0], Calendar: tc.cal}
+		ts, _ := NewSeriesTime(ctx, "time", tc.timeFreq, start, false, opts)
+
+		guess, _, err := GuessTimeFreq(ctx, ts, GuessTimeFreqOptions{Calendar: tc.cal})
+		if err != nil || guess != tc.timeFreq {
+			t.Errorf("%d: wrong guess: expected: %v actual: %v (%v)", i, tc.timeFreq, guess, err)
+		}
+	}
+}
+
+func TestGuessTimeFreqs(t *testing.T) {
+	ctx := context.Background()
+
+	hour := func(h, m int) *time.Time {
+		t := time.Date(2020, time.January, 1, h, m, 0, 0, time.UTC)
+		return &t
+	}
+
+	// Contains a nil value and a missing period
+	ts := dataframe.NewSeriesTime("time", nil, hour(0, 0), hour(1, 0), nil, hour(3, 0), hour(4, 0), hour(6, 0), hour(7, 0))
+
+	guess, reverse, err := GuessTimeFreq(ctx, ts)
+	if err != nil || guess != "1h0m0s" || reverse {
+		t.Errorf("wrong guess: expected: %v actual: %v (%v)", "1h0m0s", guess, err)
+	}
+
+	// Also contains an outlier
+	ts.Insert(6, hour(6, 30))
+	ts.Append(nil)
+
+	guesses, err := GuessTimeFreqs(ctx, ts, 2)
+	if err != nil {
+		t.Fatalf("error encountered: %v", err)
+	}
+
+	if len(guesses) != 2 {
+		t.Fatalf("wrong number of guesses: expected: %v actual: %v", 2, len(guesses))
+	}
+
+	best := guesses[0]
+	if best.TimeFreq != "1h0m0s" || best.Confidence != 0.5 {
+		t.Errorf("wrong guess: expected: %v (%v) actual: %v (%v)", "1h0m0s", 0.5, best.TimeFreq, best.Confidence)
+	}
+
+	expGaps := []dataframe.Range{dataframe.RangeFinite(1, 3), dataframe.RangeFinite(4, 5), dataframe.RangeFinite(5, 7), dataframe.RangeFinite(7, 8)}
+	if fmt.Sprint(best.Gaps) != fmt.Sprint(expGaps) {
+		t.Errorf("wrong gaps: expected: %v actual: %v", expGaps, best.Gaps)
+	}
+
+	expOutliers := []dataframe.Range{dataframe.RangeFinite(6, 6)}
+	if fmt.Sprint(best.Outliers) != fmt.Sprint(expOutliers) {
+		t.Errorf("wrong outliers: expected: %v actual: %v", expOutliers, best.Outliers)
+	}
+
+	if guesses[1].Confidence > best.Confidence {
+		t.Errorf("guesses not ordered by confidence")
+	}
+
+	if _, _, err := GuessTimeFreq(ctx, ts); err != ErrNoPattern {
+		t.Errorf("expected error: %v actual: %v", ErrNoPattern, err)
+	}
+}
+
+func TestGuessTimeFreqsFixedDuration(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	start := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	// A week of hourly data with one reading 1ms late
+	ts := dataframe.NewSeriesTime("time", nil)
+	for i := 0; i < 7*24; i++ {
+		ts.Append(start.Add(time.Duration(i) * time.Hour))
+	}
+	late := start.Add(100*time.Hour + time.Millisecond)
+	ts.Update(100, &late)
+
+	for _, hint := range []string{"", "1ms", "1ns"} {
+		guesses, err := GuessTimeFreqs(ctx, ts, 1, GuessTimeFreqOptions{Hint: hint})
+		if err != nil {
+			t.Fatalf("%q: error encountered: %v", hint, err)
+		}
+
+		expOutliers := []dataframe.Range{dataframe.RangeFinite(100, 100)}
+		if guesses[0].TimeFreq != "1h0m0s" || fmt.Sprint(guesses[0].Outliers) != fmt.Sprint(expOutliers) {
+			t.Errorf("%q: wrong guess: expected: %v %v actual: %v %v", hint, "1h0m0s", expOutliers, guesses[0].TimeFreq, guesses[0].Outliers)
+		}
+	}
+
+	// A fixed duration is evaluated the same as the equivalent (stepped) calendar interval
+	day := func(d int) *time.Time {
+		t := start.AddDate(0, 0, d)
+		return &t
+	}
+	half := start.AddDate(0, 0, 5).Add(12 * time.Hour)
+
+	for _, reverse := range []bool{false, true} {
+		vals := []interface{}{day(0), day(1), nil, day(3), day(4), &half, day(6), day(8)}
+		if reverse {
+			for i, j := 0, len(vals)-1; i < j; i, j = i+1, j-1 {
+				vals[i], vals[j] = vals[j], vals[i]
+			}
+		}
+		ts := dataframe.NewSeriesTime("time", nil, vals...)
+
+		stepped, err := GuessTimeFreqs(ctx, ts, 1, GuessTimeFreqOptions{Hint: "1D"})
+		if err != nil {
+			t.Fatalf("error encountered: %v", err)
+		}
+
+		fixed, err := GuessTimeFreqs(ctx, ts, 1, GuessTimeFreqOptions{Hint: "24h"})
+		if err != nil {
+			t.Fatalf("error encountered: %v", err)
+		}
+
+		s, f := stepped[0], fixed[0]
+		if s.Reverse != reverse || s.Confidence != f.Confidence || fmt.Sprint(s.Gaps) != fmt.Sprint(f.Gaps) || fmt.Sprint(s.Outliers) != fmt.Sprint(f.Outliers) {
+			t.Errorf("reverse %v: stepped: %+v fixed: %+v", reverse, s, f)
+		}
+	}
+}
@@ -41,6 +41,9 @@ type ValidateSeriesTimeOptions struct {
 	// MissingValue configures what must happen when a nil Value is encountered.
 	MissingValue MissingValueOption
 
+	// Calendar sets the holidays that are skipped by the business day frequency (B).
+	Calendar Calendar
+
 	// DontLock can be set to true if the Series should not be locked.
 	DontLock bool
 }
@@ -112,7 +115,7 @@ func ValidateSeriesTime(ctx context.Context, ts *dataframe.SeriesTime, timeFreq
 	rvs := []rv{}
 
 	// Perform main validation
-	gen, err := TimeIntervalGenerator(timeFreq)
+	gen, err := timeIntervalGenerator(timeFreq, opts.Calendar)
 	if err != nil {
 		return err
 	}
@@ -123,7 +126,10 @@ func ValidateSeriesTime(ctx context.Context, ts *dataframe.SeriesTime, timeFreq
 			return err
 		}
 
-		expectedTime := ntg()
+		expectedTime, err := ntg()
+		if err != nil {
+			return err
+		}
 		if actualTime == nil {
 			if opts.MissingValue == Error {
 				return &dataframe.RowError{Row: row, Err: ErrValidationFailed}
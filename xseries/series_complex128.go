@@ -5,6 +5,8 @@ package xseries
 import (
 	"bytes"
 	"context"
+	"encoding/binary"
+	"errors"
 	"fmt"
 	"golang.org/x/exp/rand"
 	"math"
@@ -18,6 +20,10 @@ import (
 	dataframe "github.com/rocketlaunchr/dataframe-go"
 )
 
+func init() {
+	dataframe.RegisterSeries("complex128", &SeriesComplex128{})
+}
+
 // SeriesComplex128 is used for series containing complex128 data.
 type SeriesComplex128 struct {
 	valFormatter dataframe.ValueToStringFormatter
@@ -586,6 +592,8 @@ func (s *SeriesComplex128) Table(opts ...dataframe.TableOptions) string {
 
 	if len(opts) == 0 {
 		opts = append(opts, dataframe.TableOptions{R: &dataframe.Range{}})
+	} else if opts[0].R == nil {
+		opts[0].R = &dataframe.Range{}
 	}
 
 	if !opts[0].DontLock {
@@ -593,9 +601,11 @@ func (s *SeriesComplex128) Table(opts ...dataframe.TableOptions) string {
 		defer s.lock.RUnlock()
 	}
 
+	display := dataframe.TableDisplayOptions(opts...)
+
 	data := [][]string{}
 
-	headers := []string{"", s.name} // row header is blank
+	headers := []string{"", display.Truncate(s.name)} // row header is blank
 	footers := []string{fmt.Sprintf("%dx%d", len(s.Values), 1), s.Type()}
 
 	if len(s.Values) > 0 {
@@ -605,8 +615,12 @@ func (s *SeriesComplex128) Table(opts ...dataframe.TableOptions) string {
 			panic(err)
 		}
 
-		for row := start; row <= end; row++ {
-			sVals := []string{fmt.Sprintf("%d:", row), s.ValueString(row, dataframe.DontLock)}
+		for _, row := range display.Rows(start, end) {
+			if row == -1 {
+				data = append(data, []string{"⋮", "⋮"})
+				continue
+			}
+			sVals := []string{fmt.Sprintf("%d:", row), display.ValueString(s, row)}
 			data = append(data, sVals)
 		}
 
@@ -630,26 +644,17 @@ func (s *SeriesComplex128) Table(opts ...dataframe.TableOptions) string {
 // String implements the fmt.Stringer interface. It does not lock the Series.
 func (s *SeriesComplex128) String() string {
 
-	count := len(s.Values)
+	display := dataframe.StringDisplayOptions()
 
 	out := "[ "
-
-	if count > 6 {
-		idx := []int{0, 1, 2, count - 3, count - 2, count - 1}
-		for j, row := range idx {
-			if j == 3 {
-				out = out + "... "
-			}
-			out = out + s.ValueString(row, dataframe.DontLock) + " "
+	for _, row := range display.Rows(0, len(s.Values)-1) {
+		if row == -1 {
+			out = out + "... "
+			continue
 		}
-		return out + "]"
-	}
-
-	for row := range s.Values {
-		out = out + s.ValueString(row, dataframe.DontLock) + " "
+		out = out + display.ValueString(s, row) + " "
 	}
 	return out + "]"
-
 }
 
 // ContainsNil will return whether or not the series contains any nil values.
@@ -1071,3 +1076,57 @@ func (s *SeriesComplex128) IsEqual(ctx context.Context, s2 dataframe.Series, opt
 
 	return true, nil
 }
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+// It allows the series to be serialized using the native binary format of DataFrame.
+func (s *SeriesComplex128) MarshalBinary() ([]byte, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	out := make([]byte, binary.MaxVarintLen64, binary.MaxVarintLen64+len(s.name)+16*len(s.Values))
+	out = append(out[:binary.PutUvarint(out, uint64(len(s.name)))], s.name...)
+
+	var buf [16]byte
+	for _, v := range s.Values {
+		binary.LittleEndian.PutUint64(buf[:8], math.Float64bits(real(v)))
+		binary.LittleEndian.PutUint64(buf[8:], math.Float64bits(imag(v)))
+		out = append(out, buf[:]...)
+	}
+
+	return out, nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (s *SeriesComplex128) UnmarshalBinary(data []byte) error {
+
+	l, n := binary.Uvarint(data)
+	if n <= 0 || l > uint64(len(data)-n) || (uint64(len(data)-n)-l)%16 != 0 {
+		return errors.New("invalid binary series")
+	}
+	name := string(data[n : n+int(l)])
+	data = data[n+int(l):]
+
+	vals := make([]complex128, 0, len(data)/16)
+	nilCount := 0
+	for i := 0; i < len(data); i += 16 {
+		re := math.Float64frombits(binary.LittleEndian.Uint64(data[i:]))
+		im := math.Float64frombits(binary.LittleEndian.Uint64(data[i+8:]))
+		v := complex(re, im)
+		if cmplx.IsNaN(v) {
+			nilCount++
+		}
+		vals = append(vals, v)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.name = name
+	s.Values = vals
+	s.nilCount = nilCount
+	if s.valFormatter == nil {
+		s.valFormatter = DefaultValueFormatter
+	}
+
+	return nil
+}